@@ -0,0 +1,109 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package baggage
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelbaggage "go.opentelemetry.io/otel/baggage"
+)
+
+const (
+	// comma-separated baggage keys copied as they are
+	KeysEnv = "OTEL_INSTRUMENTATION_BAGGAGE_ATTRIBUTE_KEYS"
+	// comma-separated prefixes of the baggage keys to copy
+	PrefixesEnv = "OTEL_INSTRUMENTATION_BAGGAGE_ATTRIBUTE_PREFIXES"
+	// set to "true" to add the selected members to the metric attributes as
+	// well, every distinct value makes a new time series
+	MetricsEnabledEnv = "OTEL_INSTRUMENTATION_BAGGAGE_METRIC_ATTRIBUTES_ENABLED"
+)
+
+// Selector picks the baggage members promoted to attributes, the attribute
+// key is the baggage key.
+type Selector struct {
+	keys     map[string]bool
+	prefixes []string
+}
+
+// NewSelector selects the members by the exact keys or by the prefixes, it
+// returns nil when nothing would be selected.
+func NewSelector(keys []string, prefixes []string) *Selector {
+	s := &Selector{keys: make(map[string]bool)}
+	for _, key := range keys {
+		if key = strings.TrimSpace(key); key != "" {
+			s.keys[key] = true
+		}
+	}
+	for _, prefix := range prefixes {
+		if prefix = strings.TrimSpace(prefix); prefix != "" {
+			s.prefixes = append(s.prefixes, prefix)
+		}
+	}
+	if len(s.keys) == 0 && len(s.prefixes) == 0 {
+		return nil
+	}
+	return s
+}
+
+// NewSelectorFromEnv reads OTEL_INSTRUMENTATION_BAGGAGE_ATTRIBUTE_KEYS and
+// OTEL_INSTRUMENTATION_BAGGAGE_ATTRIBUTE_PREFIXES.
+func NewSelectorFromEnv() *Selector {
+	return NewSelector(splitEnv(KeysEnv), splitEnv(PrefixesEnv))
+}
+
+// MetricsEnabled reports whether the selected members go to the metrics.
+func MetricsEnabled() bool {
+	val, err := strconv.ParseBool(os.Getenv(MetricsEnabledEnv))
+	return err == nil && val
+}
+
+func splitEnv(name string) []string {
+	val := os.Getenv(name)
+	if val == "" {
+		return nil
+	}
+	return strings.Split(val, ",")
+}
+
+func (s *Selector) selected(key string) bool {
+	if s.keys[key] {
+		return true
+	}
+	for _, prefix := range s.prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Attributes returns the selected members of the baggage in ctx, the
+// baggage falls back to the one of the goroutine when ctx has none.
+func (s *Selector) Attributes(ctx context.Context) []attribute.KeyValue {
+	members := otelbaggage.FromContext(ctx).Members()
+	if len(members) == 0 {
+		return nil
+	}
+	var attrs []attribute.KeyValue
+	for _, member := range members {
+		if s.selected(member.Key()) {
+			attrs = append(attrs, attribute.String(member.Key(), member.Value()))
+		}
+	}
+	return attrs
+}
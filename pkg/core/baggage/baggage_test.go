@@ -0,0 +1,138 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package baggage
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelbaggage "go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func contextWithBaggage(t *testing.T, members map[string]string) context.Context {
+	var list []otelbaggage.Member
+	for key, value := range members {
+		member, err := otelbaggage.NewMember(key, value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		list = append(list, member)
+	}
+	b, err := otelbaggage.New(list...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return otelbaggage.ContextWithBaggage(context.Background(), b)
+}
+
+func attributeMap(attrs []attribute.KeyValue) map[string]string {
+	m := make(map[string]string, len(attrs))
+	for _, attr := range attrs {
+		m[string(attr.Key)] = attr.Value.Emit()
+	}
+	return m
+}
+
+func TestSelector(t *testing.T) {
+	selector := NewSelector([]string{"tenant", " "}, []string{"exp."})
+	ctx := contextWithBaggage(t, map[string]string{"tenant": "acme", "exp.color": "blue", "user": "alice"})
+	attrs := attributeMap(selector.Attributes(ctx))
+	if len(attrs) != 2 || attrs["tenant"] != "acme" || attrs["exp.color"] != "blue" {
+		t.Fatalf("unexpected attributes %v", attrs)
+	}
+	if attrs := selector.Attributes(context.Background()); len(attrs) != 0 {
+		t.Fatalf("expected no attributes without baggage, got %v", attrs)
+	}
+	if NewSelector(nil, []string{""}) != nil {
+		t.Fatal("expected no selector without keys and prefixes")
+	}
+}
+
+func TestSelectorFromEnv(t *testing.T) {
+	t.Setenv(KeysEnv, "tenant,region")
+	t.Setenv(PrefixesEnv, "")
+	t.Setenv(MetricsEnabledEnv, "true")
+	selector := NewSelectorFromEnv()
+	if selector == nil || !selector.keys["region"] || len(selector.prefixes) != 0 {
+		t.Fatalf("unexpected selector %v", selector)
+	}
+	if !MetricsEnabled() {
+		t.Fatal("expected the metric attributes to be enabled")
+	}
+}
+
+func TestSpanProcessor(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(NewSpanProcessor(NewSelector([]string{"tenant"}, nil))),
+		sdktrace.WithSpanProcessor(sr))
+	ctx := contextWithBaggage(t, map[string]string{"tenant": "acme", "user": "alice"})
+	_, span := tp.Tracer("test").Start(ctx, "span")
+	span.End()
+	attrs := attributeMap(sr.Ended()[0].Attributes())
+	if len(attrs) != 1 || attrs["tenant"] != "acme" {
+		t.Fatalf("unexpected span attributes %v", attrs)
+	}
+}
+
+func TestWrapMeter(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	m := WrapMeter(mp.Meter("test-meter"), NewSelector([]string{"tenant"}, nil))
+	counter, err := m.Int64Counter("requests")
+	if err != nil {
+		t.Fatal(err)
+	}
+	histogram, err := m.Float64Histogram("duration")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := contextWithBaggage(t, map[string]string{"tenant": "acme"})
+	counter.Add(ctx, 1, metric.WithAttributes(attribute.String("route", "/users")))
+	// the attributes of the measurement win
+	histogram.Record(ctx, 1, metric.WithAttributes(attribute.String("tenant", "override")))
+	counter.Add(context.Background(), 1)
+
+	rm := metricdata.ResourceMetrics{}
+	if err = reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	for _, data := range rm.ScopeMetrics[0].Metrics {
+		switch data := data.Data.(type) {
+		case metricdata.Sum[int64]:
+			if len(data.DataPoints) != 2 {
+				t.Fatalf("expected a series with and without the baggage, got %v", data.DataPoints)
+			}
+			for _, dp := range data.DataPoints {
+				tenant, ok := dp.Attributes.Value("tenant")
+				if ok && (tenant.AsString() != "acme" || dp.Attributes.Len() != 2) {
+					t.Fatalf("unexpected attributes %v", dp.Attributes)
+				}
+			}
+		case metricdata.Histogram[float64]:
+			if tenant, _ := data.DataPoints[0].Attributes.Value("tenant"); tenant.AsString() != "override" {
+				t.Fatalf("unexpected attributes %v", data.DataPoints[0].Attributes)
+			}
+		}
+	}
+	if WrapMeter(mp.Meter("test-meter"), nil) != mp.Meter("test-meter") {
+		t.Fatal("expected the meter to be returned as is without selector")
+	}
+}
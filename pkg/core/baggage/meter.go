@@ -0,0 +1,199 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package baggage
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// WrapMeter adds the selected baggage members of the context passed to Add
+// and Record to the attributes of the synchronous instruments. The
+// attributes of the measurement win over the baggage ones, the observable
+// instruments have no context to read the baggage from and are left as is.
+func WrapMeter(m metric.Meter, selector *Selector) metric.Meter {
+	if m == nil || selector == nil {
+		return m
+	}
+	return &meter{Meter: m, selector: selector}
+}
+
+type meter struct {
+	metric.Meter
+	selector *Selector
+}
+
+// merge returns nil when the baggage has nothing selected so that the
+// options are passed as they are.
+func (m *meter) merge(ctx context.Context, set attribute.Set) *attribute.Set {
+	attrs := m.selector.Attributes(ctx)
+	if len(attrs) == 0 {
+		return nil
+	}
+	// the later attributes win in a set
+	merged := attribute.NewSet(append(attrs, set.ToSlice()...)...)
+	return &merged
+}
+
+func (m *meter) addOptions(ctx context.Context, options []metric.AddOption) []metric.AddOption {
+	if merged := m.merge(ctx, metric.NewAddConfig(options).Attributes()); merged != nil {
+		return []metric.AddOption{metric.WithAttributeSet(*merged)}
+	}
+	return options
+}
+
+func (m *meter) recordOptions(ctx context.Context, options []metric.RecordOption) []metric.RecordOption {
+	if merged := m.merge(ctx, metric.NewRecordConfig(options).Attributes()); merged != nil {
+		return []metric.RecordOption{metric.WithAttributeSet(*merged)}
+	}
+	return options
+}
+
+func (m *meter) Int64Counter(name string, options ...metric.Int64CounterOption) (metric.Int64Counter, error) {
+	i, err := m.Meter.Int64Counter(name, options...)
+	if err != nil {
+		return i, err
+	}
+	return &int64Counter{Int64Counter: i, m: m}, nil
+}
+
+func (m *meter) Int64UpDownCounter(name string, options ...metric.Int64UpDownCounterOption) (metric.Int64UpDownCounter, error) {
+	i, err := m.Meter.Int64UpDownCounter(name, options...)
+	if err != nil {
+		return i, err
+	}
+	return &int64UpDownCounter{Int64UpDownCounter: i, m: m}, nil
+}
+
+func (m *meter) Int64Histogram(name string, options ...metric.Int64HistogramOption) (metric.Int64Histogram, error) {
+	i, err := m.Meter.Int64Histogram(name, options...)
+	if err != nil {
+		return i, err
+	}
+	return &int64Histogram{Int64Histogram: i, m: m}, nil
+}
+
+func (m *meter) Int64Gauge(name string, options ...metric.Int64GaugeOption) (metric.Int64Gauge, error) {
+	i, err := m.Meter.Int64Gauge(name, options...)
+	if err != nil {
+		return i, err
+	}
+	return &int64Gauge{Int64Gauge: i, m: m}, nil
+}
+
+func (m *meter) Float64Counter(name string, options ...metric.Float64CounterOption) (metric.Float64Counter, error) {
+	i, err := m.Meter.Float64Counter(name, options...)
+	if err != nil {
+		return i, err
+	}
+	return &float64Counter{Float64Counter: i, m: m}, nil
+}
+
+func (m *meter) Float64UpDownCounter(name string, options ...metric.Float64UpDownCounterOption) (metric.Float64UpDownCounter, error) {
+	i, err := m.Meter.Float64UpDownCounter(name, options...)
+	if err != nil {
+		return i, err
+	}
+	return &float64UpDownCounter{Float64UpDownCounter: i, m: m}, nil
+}
+
+func (m *meter) Float64Histogram(name string, options ...metric.Float64HistogramOption) (metric.Float64Histogram, error) {
+	i, err := m.Meter.Float64Histogram(name, options...)
+	if err != nil {
+		return i, err
+	}
+	return &float64Histogram{Float64Histogram: i, m: m}, nil
+}
+
+func (m *meter) Float64Gauge(name string, options ...metric.Float64GaugeOption) (metric.Float64Gauge, error) {
+	i, err := m.Meter.Float64Gauge(name, options...)
+	if err != nil {
+		return i, err
+	}
+	return &float64Gauge{Float64Gauge: i, m: m}, nil
+}
+
+type int64Counter struct {
+	metric.Int64Counter
+	m *meter
+}
+
+func (i *int64Counter) Add(ctx context.Context, incr int64, options ...metric.AddOption) {
+	i.Int64Counter.Add(ctx, incr, i.m.addOptions(ctx, options)...)
+}
+
+type int64UpDownCounter struct {
+	metric.Int64UpDownCounter
+	m *meter
+}
+
+func (i *int64UpDownCounter) Add(ctx context.Context, incr int64, options ...metric.AddOption) {
+	i.Int64UpDownCounter.Add(ctx, incr, i.m.addOptions(ctx, options)...)
+}
+
+type int64Histogram struct {
+	metric.Int64Histogram
+	m *meter
+}
+
+func (i *int64Histogram) Record(ctx context.Context, value int64, options ...metric.RecordOption) {
+	i.Int64Histogram.Record(ctx, value, i.m.recordOptions(ctx, options)...)
+}
+
+type int64Gauge struct {
+	metric.Int64Gauge
+	m *meter
+}
+
+func (i *int64Gauge) Record(ctx context.Context, value int64, options ...metric.RecordOption) {
+	i.Int64Gauge.Record(ctx, value, i.m.recordOptions(ctx, options)...)
+}
+
+type float64Counter struct {
+	metric.Float64Counter
+	m *meter
+}
+
+func (f *float64Counter) Add(ctx context.Context, incr float64, options ...metric.AddOption) {
+	f.Float64Counter.Add(ctx, incr, f.m.addOptions(ctx, options)...)
+}
+
+type float64UpDownCounter struct {
+	metric.Float64UpDownCounter
+	m *meter
+}
+
+func (f *float64UpDownCounter) Add(ctx context.Context, incr float64, options ...metric.AddOption) {
+	f.Float64UpDownCounter.Add(ctx, incr, f.m.addOptions(ctx, options)...)
+}
+
+type float64Histogram struct {
+	metric.Float64Histogram
+	m *meter
+}
+
+func (f *float64Histogram) Record(ctx context.Context, value float64, options ...metric.RecordOption) {
+	f.Float64Histogram.Record(ctx, value, f.m.recordOptions(ctx, options)...)
+}
+
+type float64Gauge struct {
+	metric.Float64Gauge
+	m *meter
+}
+
+func (f *float64Gauge) Record(ctx context.Context, value float64, options ...metric.RecordOption) {
+	f.Float64Gauge.Record(ctx, value, f.m.recordOptions(ctx, options)...)
+}
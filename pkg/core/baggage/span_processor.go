@@ -0,0 +1,49 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package baggage
+
+import (
+	"context"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// SpanProcessor copies the selected baggage members of the parent context
+// into the attributes of every started span.
+type SpanProcessor struct {
+	selector *Selector
+}
+
+func NewSpanProcessor(selector *Selector) *SpanProcessor {
+	return &SpanProcessor{selector: selector}
+}
+
+func (p *SpanProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	if !s.IsRecording() {
+		return
+	}
+	if attrs := p.selector.Attributes(parent); len(attrs) > 0 {
+		s.SetAttributes(attrs...)
+	}
+}
+
+func (p *SpanProcessor) OnEnd(s sdktrace.ReadOnlySpan) {}
+
+func (p *SpanProcessor) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+func (p *SpanProcessor) ForceFlush(ctx context.Context) error {
+	return nil
+}
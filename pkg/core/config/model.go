@@ -0,0 +1,328 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// Configuration is the subset of the OpenTelemetry declarative configuration
+// (https://github.com/open-telemetry/opentelemetry-configuration) supported by
+// the agent.
+type Configuration struct {
+	FileFormat     string          `yaml:"file_format"`
+	Disabled       bool            `yaml:"disabled"`
+	Resource       *Resource       `yaml:"resource"`
+	Propagator     *Propagator     `yaml:"propagator"`
+	TracerProvider *TracerProvider `yaml:"tracer_provider"`
+	MeterProvider  *MeterProvider  `yaml:"meter_provider"`
+	// LoggerProvider is only decoded to be rejected by Parse, the agent does
+	// not export logs.
+	LoggerProvider *yaml.Node `yaml:"logger_provider"`
+	// Instrumentation is not part of the declarative configuration schema,
+	// it switches the instrumentations of the agent.
+	Instrumentation *Instrumentation `yaml:"instrumentation"`
+}
+
+// Instrumentation overrides OTEL_INSTRUMENTATION_COMMON_DEFAULT_ENABLED and
+// OTEL_INSTRUMENTATION_<NAME>_ENABLED, a name listed in both enabled and
+// disabled is disabled.
+type Instrumentation struct {
+	DefaultEnabled *bool       `yaml:"default_enabled"`
+	Enabled        []string    `yaml:"enabled"`
+	Disabled       []string    `yaml:"disabled"`
+	HttpFilter     *HttpFilter `yaml:"http_filter"`
+}
+
+// HttpFilter replaces the filter configured by OTEL_INSTRUMENTATION_HTTP_*,
+// the requests matching an exclude rule, or the inbound requests matching
+// none of the include rules when there are some, are not instrumented.
+type HttpFilter struct {
+	Include []utils.HttpFilterRule `yaml:"include"`
+	Exclude []utils.HttpFilterRule `yaml:"exclude"`
+}
+
+func (h *HttpFilter) NewFilter() (*utils.HttpFilter, error) {
+	return utils.NewHttpFilter(h.Include, h.Exclude)
+}
+
+// Overrides returns the state of each instrumentation listed by name.
+func (i *Instrumentation) Overrides() map[string]bool {
+	overrides := make(map[string]bool)
+	if i == nil {
+		return overrides
+	}
+	for _, name := range i.Enabled {
+		overrides[name] = true
+	}
+	for _, name := range i.Disabled {
+		overrides[name] = false
+	}
+	return overrides
+}
+
+type Resource struct {
+	Attributes     []Attribute `yaml:"attributes"`
+	AttributesList string      `yaml:"attributes_list"`
+	SchemaUrl      string      `yaml:"schema_url"`
+}
+
+type Attribute struct {
+	Name  string `yaml:"name"`
+	Value any    `yaml:"value"`
+	// Type is one of string, bool, int, double and their array forms, it is
+	// inferred from the value when absent.
+	Type string `yaml:"type"`
+}
+
+type NameStringValuePair struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
+}
+
+type Propagator struct {
+	Composite []string `yaml:"composite"`
+}
+
+type TracerProvider struct {
+	Processors []SpanProcessor `yaml:"processors"`
+	Sampler    *Sampler        `yaml:"sampler"`
+	Limits     *SpanLimits     `yaml:"limits"`
+}
+
+type SpanLimits struct {
+	AttributeValueLengthLimit *int `yaml:"attribute_value_length_limit"`
+	AttributeCountLimit       *int `yaml:"attribute_count_limit"`
+	EventCountLimit           *int `yaml:"event_count_limit"`
+	LinkCountLimit            *int `yaml:"link_count_limit"`
+}
+
+type SpanProcessor struct {
+	Batch  *BatchSpanProcessor  `yaml:"batch"`
+	Simple *SimpleSpanProcessor `yaml:"simple"`
+}
+
+type BatchSpanProcessor struct {
+	ScheduleDelay      *int     `yaml:"schedule_delay"`
+	ExportTimeout      *int     `yaml:"export_timeout"`
+	MaxQueueSize       *int     `yaml:"max_queue_size"`
+	MaxExportBatchSize *int     `yaml:"max_export_batch_size"`
+	Exporter           Exporter `yaml:"exporter"`
+}
+
+type SimpleSpanProcessor struct {
+	Exporter Exporter `yaml:"exporter"`
+}
+
+// Exporter holds exactly one of the exporters, it is shared by traces and
+// metrics, unsupported combinations are rejected when the SDK is built.
+type Exporter struct {
+	OTLP       *OTLP
+	Console    *Console
+	Zipkin     *Zipkin
+	File       *File
+	Prometheus *Prometheus
+}
+
+type OTLP struct {
+	Protocol    string                `yaml:"protocol"`
+	Endpoint    string                `yaml:"endpoint"`
+	Headers     []NameStringValuePair `yaml:"headers"`
+	HeadersList string                `yaml:"headers_list"`
+	Timeout     *int                  `yaml:"timeout"`
+	Insecure    *bool                 `yaml:"insecure"`
+	Compression string                `yaml:"compression"`
+}
+
+type Console struct{}
+
+type Zipkin struct {
+	Endpoint string `yaml:"endpoint"`
+}
+
+type File struct {
+	Path       string `yaml:"path"`
+	MaxSizeMB  *int   `yaml:"max_size_mb"`
+	MaxBackups *int   `yaml:"max_backups"`
+}
+
+type Prometheus struct {
+	Host string `yaml:"host"`
+	Port *int   `yaml:"port"`
+}
+
+type Sampler struct {
+	AlwaysOn          *struct{}
+	AlwaysOff         *struct{}
+	TraceIDRatioBased *TraceIDRatioBasedSampler
+	ParentBased       *ParentBasedSampler
+}
+
+type TraceIDRatioBasedSampler struct {
+	Ratio *float64 `yaml:"ratio"`
+}
+
+type ParentBasedSampler struct {
+	Root                   *Sampler `yaml:"root"`
+	RemoteParentSampled    *Sampler `yaml:"remote_parent_sampled"`
+	RemoteParentNotSampled *Sampler `yaml:"remote_parent_not_sampled"`
+	LocalParentSampled     *Sampler `yaml:"local_parent_sampled"`
+	LocalParentNotSampled  *Sampler `yaml:"local_parent_not_sampled"`
+}
+
+type MeterProvider struct {
+	Readers []MetricReader `yaml:"readers"`
+	Views   []View         `yaml:"views"`
+}
+
+type MetricReader struct {
+	Periodic *PeriodicMetricReader `yaml:"periodic"`
+	Pull     *PullMetricReader     `yaml:"pull"`
+}
+
+type PeriodicMetricReader struct {
+	Interval *int     `yaml:"interval"`
+	Timeout  *int     `yaml:"timeout"`
+	Exporter Exporter `yaml:"exporter"`
+}
+
+type PullMetricReader struct {
+	Exporter Exporter `yaml:"exporter"`
+}
+
+type View struct {
+	Selector *ViewSelector `yaml:"selector"`
+	Stream   *ViewStream   `yaml:"stream"`
+}
+
+type ViewSelector struct {
+	InstrumentName *string `yaml:"instrument_name"`
+	InstrumentType *string `yaml:"instrument_type"`
+	Unit           *string `yaml:"unit"`
+	MeterName      *string `yaml:"meter_name"`
+	MeterVersion   *string `yaml:"meter_version"`
+	MeterSchemaUrl *string `yaml:"meter_schema_url"`
+}
+
+type ViewStream struct {
+	Name          *string         `yaml:"name"`
+	Description   *string         `yaml:"description"`
+	Aggregation   *Aggregation    `yaml:"aggregation"`
+	AttributeKeys *IncludeExclude `yaml:"attribute_keys"`
+}
+
+type IncludeExclude struct {
+	Included []string `yaml:"included"`
+	Excluded []string `yaml:"excluded"`
+}
+
+type Aggregation struct {
+	Default                         *struct{}
+	Drop                            *struct{}
+	Sum                             *struct{}
+	LastValue                       *struct{}
+	ExplicitBucketHistogram         *ExplicitBucketHistogramAggregation
+	Base2ExponentialBucketHistogram *Base2ExponentialBucketHistogramAggregation
+}
+
+type ExplicitBucketHistogramAggregation struct {
+	Boundaries   []float64 `yaml:"boundaries"`
+	RecordMinMax *bool     `yaml:"record_min_max"`
+}
+
+type Base2ExponentialBucketHistogramAggregation struct {
+	MaxScale     *int32 `yaml:"max_scale"`
+	MaxSize      *int32 `yaml:"max_size"`
+	RecordMinMax *bool  `yaml:"record_min_max"`
+}
+
+// The declarative configuration often writes "console:" or "always_on:"
+// without a value, which yaml decodes as null. The helpers below treat the
+// presence of the key as the selection, so these polymorphic types need
+// custom unmarshalers.
+
+func decodeOneOf(value *yaml.Node, kind string, decoders map[string]func(*yaml.Node) error) error {
+	if value.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: %s must be a mapping", value.Line, kind)
+	}
+	if len(value.Content) != 2 {
+		return fmt.Errorf("line %d: %s must have exactly one entry", value.Line, kind)
+	}
+	key, v := value.Content[0].Value, value.Content[1]
+	decode, ok := decoders[key]
+	if !ok {
+		return fmt.Errorf("line %d: unsupported %s %q", value.Line, kind, key)
+	}
+	return decode(v)
+}
+
+func decodeInto[T any](target **T) func(*yaml.Node) error {
+	return func(node *yaml.Node) error {
+		*target = new(T)
+		if node.Tag == "!!null" {
+			return nil
+		}
+		return node.Decode(*target)
+	}
+}
+
+func (e *Exporter) UnmarshalYAML(value *yaml.Node) error {
+	return decodeOneOf(value, "exporter", map[string]func(*yaml.Node) error{
+		"otlp":       decodeInto(&e.OTLP),
+		"console":    decodeInto(&e.Console),
+		"zipkin":     decodeInto(&e.Zipkin),
+		"file":       decodeInto(&e.File),
+		"prometheus": decodeInto(&e.Prometheus),
+	})
+}
+
+func (e Exporter) name() string {
+	switch {
+	case e.OTLP != nil:
+		return "otlp"
+	case e.Console != nil:
+		return "console"
+	case e.Zipkin != nil:
+		return "zipkin"
+	case e.File != nil:
+		return "file"
+	case e.Prometheus != nil:
+		return "prometheus"
+	}
+	return ""
+}
+
+func (s *Sampler) UnmarshalYAML(value *yaml.Node) error {
+	return decodeOneOf(value, "sampler", map[string]func(*yaml.Node) error{
+		"always_on":            decodeInto(&s.AlwaysOn),
+		"always_off":           decodeInto(&s.AlwaysOff),
+		"trace_id_ratio_based": decodeInto(&s.TraceIDRatioBased),
+		"parent_based":         decodeInto(&s.ParentBased),
+	})
+}
+
+func (a *Aggregation) UnmarshalYAML(value *yaml.Node) error {
+	return decodeOneOf(value, "aggregation", map[string]func(*yaml.Node) error{
+		"default":                            decodeInto(&a.Default),
+		"drop":                               decodeInto(&a.Drop),
+		"sum":                                decodeInto(&a.Sum),
+		"last_value":                         decodeInto(&a.LastValue),
+		"explicit_bucket_histogram":          decodeInto(&a.ExplicitBucketHistogram),
+		"base2_exponential_bucket_histogram": decodeInto(&a.Base2ExponentialBucketHistogram),
+	})
+}
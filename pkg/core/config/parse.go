@@ -0,0 +1,80 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileEnv points to the declarative configuration file, when it is set
+// the other OTEL_* sdk environment variables are ignored, as required by the
+// specification.
+const ConfigFileEnv = "OTEL_CONFIG_FILE"
+
+// ${VAR}, ${env:VAR} and ${VAR:-default}, "$$" escapes a literal "$"
+var envRefPattern = regexp.MustCompile(`\$\$|\$\{(?:env:)?([a-zA-Z_][a-zA-Z0-9_]*)(?::-([^}]*))?\}`)
+
+func substituteEnv(content string) string {
+	return envRefPattern.ReplaceAllStringFunc(content, func(ref string) string {
+		if ref == "$$" {
+			return "$"
+		}
+		groups := envRefPattern.FindStringSubmatch(ref)
+		if v, ok := os.LookupEnv(groups[1]); ok && v != "" {
+			return v
+		}
+		return groups[2]
+	})
+}
+
+// Parse parses the declarative configuration, environment variable
+// references are substituted before decoding.
+func Parse(content []byte) (*Configuration, error) {
+	cfg := &Configuration{}
+	decoder := yaml.NewDecoder(strings.NewReader(substituteEnv(string(content))))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	if cfg.FileFormat == "" {
+		return nil, errors.New("invalid configuration: file_format is required")
+	}
+	if cfg.LoggerProvider != nil {
+		// rejected rather than ignored, the configured logs would never be
+		// exported
+		return nil, fmt.Errorf("invalid configuration: line %d: logger_provider is not supported, the agent does not export logs", cfg.LoggerProvider.Line)
+	}
+	if cfg.Instrumentation != nil && cfg.Instrumentation.HttpFilter != nil {
+		if _, err := cfg.Instrumentation.HttpFilter.NewFilter(); err != nil {
+			return nil, fmt.Errorf("invalid configuration: %w", err)
+		}
+	}
+	return cfg, nil
+}
+
+// ParseFile reads and parses the declarative configuration file.
+func ParseFile(path string) (*Configuration, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(content)
+}
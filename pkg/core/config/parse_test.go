@@ -0,0 +1,126 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+)
+
+const testConfig = `
+file_format: "0.3"
+resource:
+  attributes:
+    - name: service.name
+      value: ${SERVICE_NAME:-unknown}
+    - name: deployment.replicas
+      value: 3
+  attributes_list: team=otel
+propagator:
+  composite: [tracecontext, baggage]
+tracer_provider:
+  processors:
+    - batch:
+        schedule_delay: 1000
+        exporter:
+          console:
+    - simple:
+        exporter:
+          otlp:
+            protocol: http/protobuf
+            endpoint: ${env:OTLP_ENDPOINT}
+  sampler:
+    parent_based:
+      root:
+        trace_id_ratio_based:
+          ratio: 0.5
+meter_provider:
+  readers:
+    - periodic:
+        interval: 5000
+        exporter:
+          console: {}
+  views:
+    - selector:
+        instrument_name: http.server.request.duration
+      stream:
+        aggregation:
+          explicit_bucket_histogram:
+            boundaries: [5, 10, 100]
+        attribute_keys:
+          excluded: [server.address]
+instrumentation:
+  default_enabled: false
+  enabled: [gin, nethttp]
+  disabled: [nethttp]
+  http_filter:
+    exclude:
+      - paths: [/health]
+        methods: [GET]
+`
+
+func TestParse(t *testing.T) {
+	t.Setenv("OTLP_ENDPOINT", "http://collector:4318/v1/traces")
+	cfg, err := Parse([]byte(testConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Resource.Attributes[0].Value != "unknown" {
+		t.Fatalf("default value should be used, got %v", cfg.Resource.Attributes[0].Value)
+	}
+	if cfg.TracerProvider.Processors[0].Batch.Exporter.Console == nil {
+		t.Fatal("console exporter without value should be selected")
+	}
+	otlp := cfg.TracerProvider.Processors[1].Simple.Exporter.OTLP
+	if otlp == nil || otlp.Endpoint != "http://collector:4318/v1/traces" {
+		t.Fatalf("env reference should be substituted, got %+v", otlp)
+	}
+	if *cfg.TracerProvider.Sampler.ParentBased.Root.TraceIDRatioBased.Ratio != 0.5 {
+		t.Fatal("wrong sampler ratio")
+	}
+	if cfg.MeterProvider.Views[0].Stream.Aggregation.ExplicitBucketHistogram.Boundaries[2] != 100 {
+		t.Fatal("wrong histogram boundaries")
+	}
+	if *cfg.Instrumentation.DefaultEnabled {
+		t.Fatal("instrumentations should be disabled by default")
+	}
+	if overrides := cfg.Instrumentation.Overrides(); len(overrides) != 2 || !overrides["gin"] || overrides["nethttp"] {
+		t.Fatalf("unexpected overrides %v", overrides)
+	}
+	if rule := cfg.Instrumentation.HttpFilter.Exclude[0]; rule.Paths[0] != "/health" || rule.Methods[0] != "GET" {
+		t.Fatalf("unexpected http filter %+v", rule)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, content := range []string{
+		`tracer_provider: {}`,
+		"file_format: \"0.3\"\nunknown_section: {}",
+		"file_format: \"0.3\"\nlogger_provider:\n  processors:\n    - batch:\n        exporter:\n          console: {}",
+		"file_format: \"0.3\"\ninstrumentation:\n  http_filter:\n    exclude:\n      - path_regexes: [\"(\"]",
+		"file_format: \"0.3\"\ntracer_provider:\n  processors:\n    - batch:\n        exporter:\n          jaeger: {}",
+		"file_format: \"0.3\"\ntracer_provider:\n  processors:\n    - batch:\n        exporter:\n          console:\n          otlp:",
+	} {
+		if _, err := Parse([]byte(content)); err == nil {
+			t.Fatalf("expected error for %q", content)
+		}
+	}
+}
+
+func TestSubstituteEnv(t *testing.T) {
+	t.Setenv("FOO", "bar")
+	if v := substituteEnv("${FOO} ${env:FOO} ${MISSING:-x} ${MISSING} $$FOO"); v != "bar bar x  $FOO" {
+		t.Fatalf("unexpected substitution %q", v)
+	}
+}
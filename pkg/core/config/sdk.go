@@ -0,0 +1,523 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/core/exporter"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/exporters/zipkin"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/trace"
+	_ "google.golang.org/grpc/encoding/gzip"
+)
+
+const default_prometheus_port = 9464
+
+// SDK holds the components built from the declarative configuration, the
+// caller assembles them into providers so that it can add its own processors
+// and readers.
+type SDK struct {
+	Disabled        bool
+	Resource        *resource.Resource
+	Propagator      propagation.TextMapPropagator
+	Sampler         trace.Sampler
+	SpanLimits      *trace.SpanLimits
+	SpanProcessors  []trace.SpanProcessor
+	SpanExporters   []trace.SpanExporter
+	MetricReaders   []metric.Reader
+	MetricExporters []metric.Exporter
+	Views           []metric.View
+	// PrometheusAddr is the address to serve the prometheus pull reader on,
+	// empty when there is no such reader.
+	PrometheusAddr string
+
+	wrapSpanExporter   func(name string, e trace.SpanExporter) trace.SpanExporter
+	wrapMetricExporter func(name string, e metric.Exporter) metric.Exporter
+}
+
+// Option customizes the components built by NewSDK.
+type Option func(*SDK)
+
+// WithSpanExporterWrapper decorates every span exporter, e.g. to collect
+// export statistics.
+func WithSpanExporterWrapper(wrap func(name string, e trace.SpanExporter) trace.SpanExporter) Option {
+	return func(s *SDK) {
+		s.wrapSpanExporter = wrap
+	}
+}
+
+// WithMetricExporterWrapper decorates every push metric exporter.
+func WithMetricExporterWrapper(wrap func(name string, e metric.Exporter) metric.Exporter) Option {
+	return func(s *SDK) {
+		s.wrapMetricExporter = wrap
+	}
+}
+
+// NewSDK builds the SDK components described by the configuration.
+func NewSDK(ctx context.Context, cfg *Configuration, opts ...Option) (*SDK, error) {
+	sdk := &SDK{Disabled: cfg.Disabled}
+	for _, opt := range opts {
+		opt(sdk)
+	}
+	if cfg.Disabled {
+		return sdk, nil
+	}
+	var err error
+	if sdk.Resource, err = newResource(cfg.Resource); err != nil {
+		return nil, err
+	}
+	if sdk.Propagator, err = newPropagator(cfg.Propagator); err != nil {
+		return nil, err
+	}
+	if cfg.TracerProvider != nil {
+		if err = sdk.initTracerProvider(ctx, cfg.TracerProvider); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.MeterProvider != nil {
+		if err = sdk.initMeterProvider(ctx, cfg.MeterProvider); err != nil {
+			return nil, err
+		}
+	}
+	return sdk, nil
+}
+
+// TracerProviderOptions returns the options to build the TracerProvider.
+func (s *SDK) TracerProviderOptions() []trace.TracerProviderOption {
+	opts := []trace.TracerProviderOption{trace.WithResource(s.Resource)}
+	if s.Sampler != nil {
+		opts = append(opts, trace.WithSampler(s.Sampler))
+	}
+	if s.SpanLimits != nil {
+		opts = append(opts, trace.WithRawSpanLimits(*s.SpanLimits))
+	}
+	for _, sp := range s.SpanProcessors {
+		opts = append(opts, trace.WithSpanProcessor(sp))
+	}
+	return opts
+}
+
+// MeterProviderOptions returns the options to build the MeterProvider.
+func (s *SDK) MeterProviderOptions() []metric.Option {
+	opts := []metric.Option{metric.WithResource(s.Resource), metric.WithView(s.Views...)}
+	for _, reader := range s.MetricReaders {
+		opts = append(opts, metric.WithReader(reader))
+	}
+	return opts
+}
+
+func newResource(r *Resource) (*resource.Resource, error) {
+	attrs := resource.Default().Attributes()
+	schemaUrl := ""
+	if r != nil {
+		schemaUrl = r.SchemaUrl
+		// attributes_list has a lower priority than attributes
+		for _, pair := range strings.Split(r.AttributesList, ",") {
+			k, v, found := strings.Cut(pair, "=")
+			if found && strings.TrimSpace(k) != "" {
+				attrs = append(attrs, attribute.String(strings.TrimSpace(k), strings.TrimSpace(v)))
+			}
+		}
+		for _, a := range r.Attributes {
+			kv, err := newAttribute(a)
+			if err != nil {
+				return nil, err
+			}
+			attrs = append(attrs, kv)
+		}
+	}
+	// later attributes take precedence over the earlier ones
+	return resource.NewWithAttributes(schemaUrl, attrs...), nil
+}
+
+func newAttribute(a Attribute) (attribute.KeyValue, error) {
+	if a.Name == "" {
+		return attribute.KeyValue{}, errors.New("resource attribute name is required")
+	}
+	invalid := fmt.Errorf("invalid value %v of resource attribute %s", a.Value, a.Name)
+	switch a.Type {
+	case "", "string", "bool", "int", "double":
+		switch v := a.Value.(type) {
+		case string:
+			return attribute.String(a.Name, v), nil
+		case bool:
+			return attribute.Bool(a.Name, v), nil
+		case int:
+			if a.Type == "double" {
+				return attribute.Float64(a.Name, float64(v)), nil
+			}
+			return attribute.Int(a.Name, v), nil
+		case float64:
+			return attribute.Float64(a.Name, v), nil
+		}
+	case "string_array", "bool_array", "int_array", "double_array":
+		values, ok := a.Value.([]any)
+		if !ok {
+			return attribute.KeyValue{}, invalid
+		}
+		strs, bools, ints, doubles := make([]string, 0), make([]bool, 0), make([]int, 0), make([]float64, 0)
+		for _, value := range values {
+			switch v := value.(type) {
+			case string:
+				strs = append(strs, v)
+			case bool:
+				bools = append(bools, v)
+			case int:
+				ints = append(ints, v)
+				doubles = append(doubles, float64(v))
+			case float64:
+				doubles = append(doubles, v)
+			}
+		}
+		switch {
+		case a.Type == "string_array" && len(strs) == len(values):
+			return attribute.StringSlice(a.Name, strs), nil
+		case a.Type == "bool_array" && len(bools) == len(values):
+			return attribute.BoolSlice(a.Name, bools), nil
+		case a.Type == "int_array" && len(ints) == len(values):
+			return attribute.IntSlice(a.Name, ints), nil
+		case a.Type == "double_array" && len(doubles) == len(values):
+			return attribute.Float64Slice(a.Name, doubles), nil
+		}
+	}
+	return attribute.KeyValue{}, invalid
+}
+
+func newPropagator(p *Propagator) (propagation.TextMapPropagator, error) {
+	if p == nil {
+		return nil, nil
+	}
+	propagators := make([]propagation.TextMapPropagator, 0, len(p.Composite))
+	for _, name := range p.Composite {
+		switch name {
+		case "tracecontext":
+			propagators = append(propagators, propagation.TraceContext{})
+		case "baggage":
+			propagators = append(propagators, propagation.Baggage{})
+		case "none":
+		default:
+			return nil, fmt.Errorf("unsupported propagator %s", name)
+		}
+	}
+	return propagation.NewCompositeTextMapPropagator(propagators...), nil
+}
+
+func (s *SDK) initTracerProvider(ctx context.Context, tp *TracerProvider) error {
+	if tp.Sampler != nil {
+		sampler, err := newSampler(tp.Sampler)
+		if err != nil {
+			return err
+		}
+		s.Sampler = sampler
+	}
+	if tp.Limits != nil {
+		limits := trace.NewSpanLimits()
+		setIfPresent(&limits.AttributeValueLengthLimit, tp.Limits.AttributeValueLengthLimit)
+		setIfPresent(&limits.AttributeCountLimit, tp.Limits.AttributeCountLimit)
+		setIfPresent(&limits.EventCountLimit, tp.Limits.EventCountLimit)
+		setIfPresent(&limits.LinkCountLimit, tp.Limits.LinkCountLimit)
+		s.SpanLimits = &limits
+	}
+	for _, p := range tp.Processors {
+		switch {
+		case p.Batch != nil:
+			exp, err := s.newSpanExporter(ctx, p.Batch.Exporter)
+			if err != nil {
+				return err
+			}
+			opts := make([]trace.BatchSpanProcessorOption, 0, 4)
+			if p.Batch.ScheduleDelay != nil {
+				opts = append(opts, trace.WithBatchTimeout(millis(*p.Batch.ScheduleDelay)))
+			}
+			if p.Batch.ExportTimeout != nil {
+				opts = append(opts, trace.WithExportTimeout(millis(*p.Batch.ExportTimeout)))
+			}
+			if p.Batch.MaxQueueSize != nil {
+				opts = append(opts, trace.WithMaxQueueSize(*p.Batch.MaxQueueSize))
+			}
+			if p.Batch.MaxExportBatchSize != nil {
+				opts = append(opts, trace.WithMaxExportBatchSize(*p.Batch.MaxExportBatchSize))
+			}
+			s.SpanExporters = append(s.SpanExporters, exp)
+			s.SpanProcessors = append(s.SpanProcessors, trace.NewBatchSpanProcessor(exp, opts...))
+		case p.Simple != nil:
+			exp, err := s.newSpanExporter(ctx, p.Simple.Exporter)
+			if err != nil {
+				return err
+			}
+			s.SpanExporters = append(s.SpanExporters, exp)
+			s.SpanProcessors = append(s.SpanProcessors, trace.NewSimpleSpanProcessor(exp))
+		default:
+			return errors.New("span processor must be either batch or simple")
+		}
+	}
+	return nil
+}
+
+func newSampler(s *Sampler) (trace.Sampler, error) {
+	switch {
+	case s.AlwaysOn != nil:
+		return trace.AlwaysSample(), nil
+	case s.AlwaysOff != nil:
+		return trace.NeverSample(), nil
+	case s.TraceIDRatioBased != nil:
+		ratio := 1.0
+		if s.TraceIDRatioBased.Ratio != nil {
+			ratio = *s.TraceIDRatioBased.Ratio
+		}
+		return trace.TraceIDRatioBased(ratio), nil
+	case s.ParentBased != nil:
+		root := trace.AlwaysSample()
+		if s.ParentBased.Root != nil {
+			var err error
+			if root, err = newSampler(s.ParentBased.Root); err != nil {
+				return nil, err
+			}
+		}
+		opts := make([]trace.ParentBasedSamplerOption, 0, 4)
+		for _, delegate := range []struct {
+			sampler *Sampler
+			option  func(trace.Sampler) trace.ParentBasedSamplerOption
+		}{
+			{s.ParentBased.RemoteParentSampled, trace.WithRemoteParentSampled},
+			{s.ParentBased.RemoteParentNotSampled, trace.WithRemoteParentNotSampled},
+			{s.ParentBased.LocalParentSampled, trace.WithLocalParentSampled},
+			{s.ParentBased.LocalParentNotSampled, trace.WithLocalParentNotSampled},
+		} {
+			if delegate.sampler == nil {
+				continue
+			}
+			sampler, err := newSampler(delegate.sampler)
+			if err != nil {
+				return nil, err
+			}
+			opts = append(opts, delegate.option(sampler))
+		}
+		return trace.ParentBased(root, opts...), nil
+	}
+	return nil, errors.New("sampler must not be empty")
+}
+
+func (s *SDK) newSpanExporter(ctx context.Context, e Exporter) (trace.SpanExporter, error) {
+	exp, err := newSpanExporter(ctx, e)
+	if err != nil || s.wrapSpanExporter == nil {
+		return exp, err
+	}
+	return s.wrapSpanExporter(e.name(), exp), nil
+}
+
+func newSpanExporter(ctx context.Context, e Exporter) (trace.SpanExporter, error) {
+	switch {
+	case e.OTLP != nil:
+		headers, timeout := otlpHeadersAndTimeout(e.OTLP)
+		switch e.OTLP.Protocol {
+		case "grpc":
+			opts := []otlptracegrpc.Option{otlptracegrpc.WithHeaders(headers), otlptracegrpc.WithTimeout(timeout)}
+			if e.OTLP.Endpoint != "" {
+				opts = append(opts, otlptracegrpc.WithEndpointURL(e.OTLP.Endpoint))
+			}
+			if e.OTLP.Insecure != nil && *e.OTLP.Insecure {
+				opts = append(opts, otlptracegrpc.WithInsecure())
+			}
+			if e.OTLP.Compression == "gzip" {
+				opts = append(opts, otlptracegrpc.WithCompressor("gzip"))
+			}
+			return otlptrace.New(ctx, otlptracegrpc.NewClient(opts...))
+		case "http/json":
+			endpoint := e.OTLP.Endpoint
+			if endpoint == "" {
+				endpoint = exporter.DefaultHttpEndpoint(exporter.SignalTraces)
+			}
+			return otlptrace.New(ctx, exporter.NewTraceHttpJsonClient(exporter.HttpJsonConfig{
+				Endpoint: endpoint, Headers: headers, Timeout: timeout,
+			}))
+		case "", "http/protobuf":
+			opts := []otlptracehttp.Option{otlptracehttp.WithHeaders(headers), otlptracehttp.WithTimeout(timeout)}
+			if e.OTLP.Endpoint != "" {
+				opts = append(opts, otlptracehttp.WithEndpointURL(e.OTLP.Endpoint))
+			}
+			if e.OTLP.Insecure != nil && *e.OTLP.Insecure {
+				opts = append(opts, otlptracehttp.WithInsecure())
+			}
+			if e.OTLP.Compression == "gzip" {
+				opts = append(opts, otlptracehttp.WithCompression(otlptracehttp.GzipCompression))
+			}
+			return otlptrace.New(ctx, otlptracehttp.NewClient(opts...))
+		default:
+			return nil, fmt.Errorf("unsupported otlp protocol %s", e.OTLP.Protocol)
+		}
+	case e.Console != nil:
+		return stdouttrace.New()
+	case e.Zipkin != nil:
+		return zipkin.New(e.Zipkin.Endpoint)
+	case e.File != nil:
+		return exporter.NewFileSpanExporter(ctx, newFileConfig(exporter.SignalTraces, e.File))
+	}
+	return nil, errors.New("unsupported span exporter")
+}
+
+func (s *SDK) initMeterProvider(ctx context.Context, mp *MeterProvider) error {
+	for _, r := range mp.Readers {
+		switch {
+		case r.Periodic != nil:
+			exp, err := s.newMetricExporter(ctx, r.Periodic.Exporter)
+			if err != nil {
+				return err
+			}
+			opts := make([]metric.PeriodicReaderOption, 0, 2)
+			if r.Periodic.Interval != nil {
+				opts = append(opts, metric.WithInterval(millis(*r.Periodic.Interval)))
+			}
+			if r.Periodic.Timeout != nil {
+				opts = append(opts, metric.WithTimeout(millis(*r.Periodic.Timeout)))
+			}
+			s.MetricExporters = append(s.MetricExporters, exp)
+			s.MetricReaders = append(s.MetricReaders, metric.NewPeriodicReader(exp, opts...))
+		case r.Pull != nil:
+			if r.Pull.Exporter.Prometheus == nil {
+				return errors.New("pull metric reader only supports the prometheus exporter")
+			}
+			if s.PrometheusAddr != "" {
+				return errors.New("only one prometheus metric reader is supported")
+			}
+			reader, err := prometheus.New()
+			if err != nil {
+				return err
+			}
+			port := default_prometheus_port
+			if r.Pull.Exporter.Prometheus.Port != nil {
+				port = *r.Pull.Exporter.Prometheus.Port
+			}
+			s.PrometheusAddr = r.Pull.Exporter.Prometheus.Host + ":" + strconv.Itoa(port)
+			s.MetricReaders = append(s.MetricReaders, reader)
+		default:
+			return errors.New("metric reader must be either periodic or pull")
+		}
+	}
+	for _, v := range mp.Views {
+		view, err := NewView(v)
+		if err != nil {
+			return err
+		}
+		s.Views = append(s.Views, view)
+	}
+	return nil
+}
+
+func (s *SDK) newMetricExporter(ctx context.Context, e Exporter) (metric.Exporter, error) {
+	exp, err := newMetricExporter(ctx, e)
+	if err != nil || s.wrapMetricExporter == nil {
+		return exp, err
+	}
+	return s.wrapMetricExporter(e.name(), exp), nil
+}
+
+func newMetricExporter(ctx context.Context, e Exporter) (metric.Exporter, error) {
+	switch {
+	case e.OTLP != nil:
+		headers, timeout := otlpHeadersAndTimeout(e.OTLP)
+		switch e.OTLP.Protocol {
+		case "grpc":
+			opts := []otlpmetricgrpc.Option{otlpmetricgrpc.WithHeaders(headers), otlpmetricgrpc.WithTimeout(timeout)}
+			if e.OTLP.Endpoint != "" {
+				opts = append(opts, otlpmetricgrpc.WithEndpointURL(e.OTLP.Endpoint))
+			}
+			if e.OTLP.Insecure != nil && *e.OTLP.Insecure {
+				opts = append(opts, otlpmetricgrpc.WithInsecure())
+			}
+			if e.OTLP.Compression == "gzip" {
+				opts = append(opts, otlpmetricgrpc.WithCompressor("gzip"))
+			}
+			return otlpmetricgrpc.New(ctx, opts...)
+		case "http/json":
+			endpoint := e.OTLP.Endpoint
+			if endpoint == "" {
+				endpoint = exporter.DefaultHttpEndpoint(exporter.SignalMetrics)
+			}
+			return exporter.NewMetricHttpJsonExporter(exporter.HttpJsonConfig{
+				Endpoint: endpoint, Headers: headers, Timeout: timeout,
+			}), nil
+		case "", "http/protobuf":
+			opts := []otlpmetrichttp.Option{otlpmetrichttp.WithHeaders(headers), otlpmetrichttp.WithTimeout(timeout)}
+			if e.OTLP.Endpoint != "" {
+				opts = append(opts, otlpmetrichttp.WithEndpointURL(e.OTLP.Endpoint))
+			}
+			if e.OTLP.Insecure != nil && *e.OTLP.Insecure {
+				opts = append(opts, otlpmetrichttp.WithInsecure())
+			}
+			if e.OTLP.Compression == "gzip" {
+				opts = append(opts, otlpmetrichttp.WithCompression(otlpmetrichttp.GzipCompression))
+			}
+			return otlpmetrichttp.New(ctx, opts...)
+		default:
+			return nil, fmt.Errorf("unsupported otlp protocol %s", e.OTLP.Protocol)
+		}
+	case e.Console != nil:
+		return stdoutmetric.New()
+	case e.File != nil:
+		return exporter.NewFileMetricExporter(newFileConfig(exporter.SignalMetrics, e.File))
+	}
+	return nil, errors.New("unsupported metric exporter")
+}
+
+func otlpHeadersAndTimeout(o *OTLP) (map[string]string, time.Duration) {
+	// headers has a higher priority than headers_list
+	headers := exporter.ParseHeaders(o.HeadersList)
+	for _, h := range o.Headers {
+		headers[h.Name] = h.Value
+	}
+	timeout := 10 * time.Second
+	if o.Timeout != nil {
+		timeout = millis(*o.Timeout)
+	}
+	return headers, timeout
+}
+
+func newFileConfig(signal string, f *File) exporter.FileConfig {
+	cfg := exporter.DefaultFileConfig(signal)
+	if f.Path != "" {
+		cfg.Path = f.Path
+	}
+	setIfPresent(&cfg.MaxSizeMB, f.MaxSizeMB)
+	setIfPresent(&cfg.MaxBackups, f.MaxBackups)
+	return cfg
+}
+
+func setIfPresent[T any](target *T, value *T) {
+	if value != nil {
+		*target = *value
+	}
+}
+
+func millis(ms int) time.Duration {
+	return time.Duration(ms) * time.Millisecond
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestNewSDK(t *testing.T) {
+	cfg, err := Parse([]byte(testConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	sdk, err := NewSDK(ctx, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sdk.SpanProcessors) != 2 || len(sdk.SpanExporters) != 2 {
+		t.Fatal("expected 2 span processors")
+	}
+	if len(sdk.MetricReaders) != 1 || len(sdk.Views) != 1 {
+		t.Fatal("expected 1 metric reader and 1 view")
+	}
+	if sdk.Sampler.Description() != trace.ParentBased(trace.TraceIDRatioBased(0.5)).Description() {
+		t.Fatalf("unexpected sampler %s", sdk.Sampler.Description())
+	}
+	if v, ok := sdk.Resource.Set().Value("service.name"); !ok || v.AsString() != "unknown" {
+		t.Fatal("wrong service name")
+	}
+	if v, ok := sdk.Resource.Set().Value("team"); !ok || v.AsString() != "otel" {
+		t.Fatal("attributes_list should be applied")
+	}
+	if v, ok := sdk.Resource.Set().Value("deployment.replicas"); !ok || v.AsInt64() != 3 {
+		t.Fatal("int attribute should be kept as int")
+	}
+	if len(sdk.Propagator.Fields()) != 3 {
+		t.Fatalf("unexpected propagator fields %v", sdk.Propagator.Fields())
+	}
+}
+
+func TestNewSDKDisabled(t *testing.T) {
+	sdk, err := NewSDK(context.Background(), &Configuration{FileFormat: "0.3", Disabled: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sdk.Disabled || len(sdk.SpanProcessors) != 0 {
+		t.Fatal("disabled sdk should not build any component")
+	}
+}
+
+func TestNewView(t *testing.T) {
+	name := "test.histogram"
+	view, err := NewView(View{
+		Selector: &ViewSelector{InstrumentName: &name},
+		Stream: &ViewStream{
+			Aggregation:   &Aggregation{ExplicitBucketHistogram: &ExplicitBucketHistogramAggregation{Boundaries: []float64{1, 2}}},
+			AttributeKeys: &IncludeExclude{Excluded: []string{"server.address"}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reader := metric.NewManualReader()
+	mp := metric.NewMeterProvider(metric.WithReader(reader), metric.WithView(view))
+	h, _ := mp.Meter("test").Float64Histogram(name)
+	ctx := context.Background()
+	h.Record(ctx, 1.5, otelmetric.WithAttributes(attribute.String("server.address", "a"), attribute.String("http.route", "/")))
+	rm := &metricdata.ResourceMetrics{}
+	_ = reader.Collect(ctx, rm)
+	dp := rm.ScopeMetrics[0].Metrics[0].Data.(metricdata.Histogram[float64]).DataPoints[0]
+	if len(dp.Bounds) != 2 {
+		t.Fatalf("unexpected bounds %v", dp.Bounds)
+	}
+	if _, ok := dp.Attributes.Value("server.address"); ok {
+		t.Fatal("server.address should be dropped")
+	}
+	if _, ok := dp.Attributes.Value("http.route"); !ok {
+		t.Fatal("http.route should be kept")
+	}
+	if _, err = NewView(View{Selector: &ViewSelector{InstrumentName: &name}, Stream: &ViewStream{
+		Aggregation: &Aggregation{ExplicitBucketHistogram: &ExplicitBucketHistogramAggregation{Boundaries: []float64{2, 1}}},
+	}}); err == nil {
+		t.Fatal("decreasing boundaries should be rejected")
+	}
+}
+
+func TestParseViews(t *testing.T) {
+	views, err := ParseViews([]byte(`
+views:
+  - selector:
+      instrument_name: http.client.request.duration
+    stream:
+      aggregation:
+        base2_exponential_bucket_histogram:
+          max_size: 80
+  - selector:
+      instrument_type: histogram
+    stream:
+      attribute_keys:
+        included: [http.request.method]
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(views) != 2 {
+		t.Fatalf("expected 2 views, got %d", len(views))
+	}
+	if _, err = ParseViews([]byte("views:\n  - selector:\n      instrument_type: timer")); err == nil {
+		t.Fatal("unknown instrument type should be rejected")
+	}
+	if _, err = ParseViews([]byte("view: []")); err == nil {
+		t.Fatal("unknown field should be rejected")
+	}
+}
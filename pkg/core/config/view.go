@@ -0,0 +1,198 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"gopkg.in/yaml.v3"
+)
+
+// MetricViewsFileEnv points to a yaml file holding the views applied to the
+// built-in metrics when no declarative configuration file is used. The views
+// are written as the meter_provider.views section of the declarative
+// configuration, e.g.
+//
+//	views:
+//	  - selector:
+//	      instrument_name: http.server.request.duration
+//	    stream:
+//	      aggregation:
+//	        base2_exponential_bucket_histogram:
+//	      attribute_keys:
+//	        excluded: [server.address, server.port]
+const MetricViewsFileEnv = "OTEL_INSTRUMENTATION_METRICS_VIEWS_FILE"
+
+type viewsFile struct {
+	Views []View `yaml:"views"`
+}
+
+// ParseViews parses the views and converts them into metric.View.
+func ParseViews(content []byte) ([]metric.View, error) {
+	f := &viewsFile{}
+	decoder := yaml.NewDecoder(strings.NewReader(substituteEnv(string(content))))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(f); err != nil {
+		return nil, fmt.Errorf("invalid metric views: %w", err)
+	}
+	views := make([]metric.View, 0, len(f.Views))
+	for _, v := range f.Views {
+		view, err := NewView(v)
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, view)
+	}
+	return views, nil
+}
+
+// ParseViewsFile reads and parses the views file.
+func ParseViewsFile(path string) ([]metric.View, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseViews(content)
+}
+
+var instrumentKinds = map[string]metric.InstrumentKind{
+	"counter":                    metric.InstrumentKindCounter,
+	"up_down_counter":            metric.InstrumentKindUpDownCounter,
+	"histogram":                  metric.InstrumentKindHistogram,
+	"gauge":                      metric.InstrumentKindGauge,
+	"observable_counter":         metric.InstrumentKindObservableCounter,
+	"observable_up_down_counter": metric.InstrumentKindObservableUpDownCounter,
+	"observable_gauge":           metric.InstrumentKindObservableGauge,
+}
+
+// NewView converts the declarative view into a metric.View.
+func NewView(v View) (metric.View, error) {
+	if v.Selector == nil {
+		return nil, fmt.Errorf("view selector is required")
+	}
+	criteria := metric.Instrument{}
+	if v.Selector.InstrumentName != nil {
+		criteria.Name = *v.Selector.InstrumentName
+	}
+	if v.Selector.InstrumentType != nil {
+		kind, ok := instrumentKinds[*v.Selector.InstrumentType]
+		if !ok {
+			return nil, fmt.Errorf("unsupported instrument type %s", *v.Selector.InstrumentType)
+		}
+		criteria.Kind = kind
+	}
+	if v.Selector.Unit != nil {
+		criteria.Unit = *v.Selector.Unit
+	}
+	if v.Selector.MeterName != nil {
+		criteria.Scope.Name = *v.Selector.MeterName
+	}
+	if v.Selector.MeterVersion != nil {
+		criteria.Scope.Version = *v.Selector.MeterVersion
+	}
+	if v.Selector.MeterSchemaUrl != nil {
+		criteria.Scope.SchemaURL = *v.Selector.MeterSchemaUrl
+	}
+	mask := metric.Stream{}
+	if v.Stream != nil {
+		if v.Stream.Name != nil {
+			mask.Name = *v.Stream.Name
+		}
+		if v.Stream.Description != nil {
+			mask.Description = *v.Stream.Description
+		}
+		if v.Stream.Aggregation != nil {
+			aggregation, err := NewAggregation(*v.Stream.Aggregation)
+			if err != nil {
+				return nil, err
+			}
+			mask.Aggregation = aggregation
+		}
+		if v.Stream.AttributeKeys != nil {
+			mask.AttributeFilter = NewAttributeFilter(v.Stream.AttributeKeys.Included, v.Stream.AttributeKeys.Excluded)
+		}
+	}
+	return metric.NewView(criteria, mask), nil
+}
+
+// NewAggregation converts the declarative aggregation, nil means the default
+// aggregation of the instrument.
+func NewAggregation(a Aggregation) (metric.Aggregation, error) {
+	switch {
+	case a.Default != nil:
+		return nil, nil
+	case a.Drop != nil:
+		return metric.AggregationDrop{}, nil
+	case a.Sum != nil:
+		return metric.AggregationSum{}, nil
+	case a.LastValue != nil:
+		return metric.AggregationLastValue{}, nil
+	case a.ExplicitBucketHistogram != nil:
+		h := a.ExplicitBucketHistogram
+		for i := 1; i < len(h.Boundaries); i++ {
+			if h.Boundaries[i] <= h.Boundaries[i-1] {
+				return nil, fmt.Errorf("histogram boundaries must be strictly increasing: %v", h.Boundaries)
+			}
+		}
+		return metric.AggregationExplicitBucketHistogram{
+			Boundaries: h.Boundaries,
+			NoMinMax:   h.RecordMinMax != nil && !*h.RecordMinMax,
+		}, nil
+	case a.Base2ExponentialBucketHistogram != nil:
+		h := a.Base2ExponentialBucketHistogram
+		aggregation := metric.AggregationBase2ExponentialHistogram{
+			MaxSize:  160,
+			MaxScale: 20,
+			NoMinMax: h.RecordMinMax != nil && !*h.RecordMinMax,
+		}
+		if h.MaxSize != nil {
+			aggregation.MaxSize = *h.MaxSize
+		}
+		if h.MaxScale != nil {
+			aggregation.MaxScale = *h.MaxScale
+		}
+		if aggregation.MaxScale > 20 || aggregation.MaxScale < -10 {
+			return nil, fmt.Errorf("histogram max_scale must be in [-10, 20]: %d", aggregation.MaxScale)
+		}
+		if aggregation.MaxSize <= 0 {
+			return nil, fmt.Errorf("histogram max_size must be positive: %d", aggregation.MaxSize)
+		}
+		return aggregation, nil
+	}
+	return nil, nil
+}
+
+// NewAttributeFilter keeps the included keys(all keys when empty) and drops
+// the excluded ones.
+func NewAttributeFilter(included, excluded []string) attribute.Filter {
+	allow := make(map[attribute.Key]bool, len(included))
+	for _, k := range included {
+		allow[attribute.Key(k)] = true
+	}
+	deny := make(map[attribute.Key]bool, len(excluded))
+	for _, k := range excluded {
+		deny[attribute.Key(k)] = true
+	}
+	return func(kv attribute.KeyValue) bool {
+		if deny[kv.Key] {
+			return false
+		}
+		return len(allow) == 0 || allow[kv.Key]
+	}
+}
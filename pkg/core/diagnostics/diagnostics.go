@@ -0,0 +1,120 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package diagnostics
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/instrumenter"
+)
+
+// InstrumentationManifest is the json list of rule bundles matched at
+// compile time, the tool assigns it from the generated otel_importer.go via
+// go:linkname.
+var InstrumentationManifest string
+
+const (
+	pathPrefix = "/debug/otel"
+	redacted   = "<redacted>"
+)
+
+var startTime = time.Now()
+
+// values of these variables may carry credentials
+var sensitiveEnvWords = []string{"HEADERS", "TOKEN", "KEY", "PASSWORD", "SECRET", "CREDENTIAL"}
+
+// EnablerStates reports whether each registered instrumentation is enabled.
+var EnablerStates = instrumenter.InstrumentEnablerStates
+
+// Handler serves the self-diagnostics pages, all of them are rendered as json
+// so that they can be inspected with curl and jq.
+func Handler() http.Handler {
+	mux := http.NewServeMux()
+	pages := map[string]func() any{
+		"config":    configPage,
+		"manifest":  manifestPage,
+		"enablers":  func() any { return EnablerStates() },
+		"spans":     spansPage,
+		"exporters": exportersPage,
+	}
+	names := make([]string, 0, len(pages))
+	for name, page := range pages {
+		names = append(names, pathPrefix+"/"+name)
+		mux.HandleFunc(pathPrefix+"/"+name, jsonHandler(page))
+	}
+	sort.Strings(names)
+	mux.HandleFunc(pathPrefix, jsonHandler(func() any { return names }))
+	return mux
+}
+
+// Serve serves the self-diagnostics pages on addr.
+func Serve(addr string) {
+	log.Printf("serving self-diagnostics at %s%s", addr, pathPrefix)
+	err := http.ListenAndServe(addr, Handler())
+	if err != nil {
+		log.Printf("error serving self-diagnostics: %v", err)
+	}
+}
+
+func jsonHandler(page func() any) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(page()); err != nil {
+			log.Printf("failed to render self-diagnostics page %s: %v", r.URL.Path, err)
+		}
+	}
+}
+
+func configPage() any {
+	env := make(map[string]string)
+	for _, kv := range os.Environ() {
+		k, v, _ := strings.Cut(kv, "=")
+		if !strings.HasPrefix(k, "OTEL_") {
+			continue
+		}
+		if isSensitive(k) && v != "" {
+			v = redacted
+		}
+		env[k] = v
+	}
+	return map[string]any{
+		"start_time": startTime,
+		"uptime":     time.Since(startTime).String(),
+		"env":        env,
+	}
+}
+
+func isSensitive(key string) bool {
+	for _, word := range sensitiveEnvWords {
+		if strings.Contains(key, word) {
+			return true
+		}
+	}
+	return false
+}
+
+func manifestPage() any {
+	if InstrumentationManifest == "" {
+		return []any{}
+	}
+	return json.RawMessage(InstrumentationManifest)
+}
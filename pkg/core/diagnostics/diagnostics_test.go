@@ -0,0 +1,125 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnostics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/instrumenter"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type failingExporter struct {
+	tracetest.NoopExporter
+}
+
+func (*failingExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	return errors.New("connection refused")
+}
+
+func get(t *testing.T, path string, v any) {
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+	if rec.Code != 200 {
+		t.Fatalf("unexpected status %d of %s", rec.Code, path)
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), v); err != nil {
+		t.Fatalf("invalid json of %s: %v", path, err)
+	}
+}
+
+func TestSpanRecorder(t *testing.T) {
+	r := NewSpanRecorder(2)
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(r))
+	tracer := tp.Tracer("test")
+	for _, name := range []string{"a", "b", "c"} {
+		_, span := tracer.Start(context.Background(), name)
+		span.SetAttributes(attribute.String("key", name))
+		span.End()
+	}
+	var spans []spanSummary
+	get(t, "/debug/otel/spans", &spans)
+	if len(spans) != 2 || spans[0].Name != "c" || spans[1].Name != "b" {
+		t.Fatalf("unexpected spans %+v", spans)
+	}
+	if spans[0].Attributes["key"] != "c" {
+		t.Fatalf("unexpected attributes %v", spans[0].Attributes)
+	}
+	if r.Ended() != 3 {
+		t.Fatalf("expected 3 ended spans, got %d", r.Ended())
+	}
+}
+
+func TestExporterStats(t *testing.T) {
+	NewSpanRecorder(0)
+	ok := WrapSpanExporter("console", tracetest.NewNoopExporter())
+	failing := WrapSpanExporter("otlp", &failingExporter{})
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(recorder),
+		sdktrace.WithSyncer(ok),
+		sdktrace.WithSyncer(failing))
+	_, span := tp.Tracer("test").Start(context.Background(), "span")
+	span.End()
+
+	var result []exporterStats
+	get(t, "/debug/otel/exporters", &result)
+	got := map[string]exporterStats{}
+	for _, s := range result {
+		got[s.Name] = s
+	}
+	if s := got["console"]; s.ExportedItems != 1 || s.FailedExports != 0 || *s.PendingSpans != 0 {
+		t.Fatalf("unexpected console stats %+v", s)
+	}
+	if s := got["otlp"]; s.FailedExports != 1 || s.LastError != "connection refused" || s.LastErrorTime == nil {
+		t.Fatalf("unexpected otlp stats %+v", s)
+	}
+}
+
+func TestConfigAndEnablers(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_HEADERS", "authorization=secret")
+	t.Setenv("OTEL_SERVICE_NAME", "demo")
+	t.Setenv("OTEL_INSTRUMENTATION_GIN_ENABLED", "false")
+	var cfg struct {
+		Env map[string]string `json:"env"`
+	}
+	get(t, "/debug/otel/config", &cfg)
+	if cfg.Env["OTEL_EXPORTER_OTLP_HEADERS"] != redacted || cfg.Env["OTEL_SERVICE_NAME"] != "demo" {
+		t.Fatalf("unexpected config %v", cfg.Env)
+	}
+	instrumenter.RegisterInstrumentEnabler("gin", true)
+	var enablers []instrumenter.InstrumentEnablerState
+	get(t, "/debug/otel/enablers", &enablers)
+	if len(enablers) != 1 || enablers[0].Name != "gin" || enablers[0].Enabled {
+		t.Fatalf("gin should be disabled, got %v", enablers)
+	}
+	InstrumentationManifest = `[{"ImportPath":"github.com/gin-gonic/gin"}]`
+	defer func() { InstrumentationManifest = "" }()
+	var manifest []map[string]any
+	get(t, "/debug/otel/manifest", &manifest)
+	if len(manifest) != 1 {
+		t.Fatalf("unexpected manifest %v", manifest)
+	}
+	var index []string
+	get(t, "/debug/otel", &index)
+	if len(index) != 5 {
+		t.Fatalf("unexpected index %v", index)
+	}
+}
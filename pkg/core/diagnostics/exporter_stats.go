@@ -0,0 +1,117 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package diagnostics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+type exporterStats struct {
+	Name          string     `json:"name"`
+	Signal        string     `json:"signal"`
+	Exports       int64      `json:"exports"`
+	FailedExports int64      `json:"failed_exports"`
+	ExportedItems int64      `json:"exported_items,omitempty"`
+	FailedItems   int64      `json:"failed_items,omitempty"`
+	LastError     string     `json:"last_error,omitempty"`
+	LastErrorTime *time.Time `json:"last_error_time,omitempty"`
+	// PendingSpans approximates the spans waiting in the batch queue, the
+	// spans dropped by a full queue are counted in as well.
+	PendingSpans *int64 `json:"pending_spans,omitempty"`
+}
+
+var (
+	statsMu sync.Mutex
+	stats   []*exporterStats
+)
+
+func newExporterStats(name, signal string) *exporterStats {
+	s := &exporterStats{Name: name, Signal: signal}
+	statsMu.Lock()
+	stats = append(stats, s)
+	statsMu.Unlock()
+	return s
+}
+
+func (s *exporterStats) record(items int, err error) {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+	s.Exports++
+	if err != nil {
+		s.FailedExports++
+		s.FailedItems += int64(items)
+		s.LastError = err.Error()
+		now := time.Now()
+		s.LastErrorTime = &now
+	} else {
+		s.ExportedItems += int64(items)
+	}
+}
+
+func exportersPage() any {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+	result := make([]exporterStats, 0, len(stats))
+	for _, s := range stats {
+		c := *s
+		if c.Signal == "traces" && recorder != nil {
+			pending := recorder.Ended() - c.ExportedItems - c.FailedItems
+			c.PendingSpans = &pending
+		}
+		result = append(result, c)
+	}
+	return result
+}
+
+type spanExporter struct {
+	sdktrace.SpanExporter
+	stats *exporterStats
+}
+
+// WrapSpanExporter counts the exports and errors of the span exporter.
+func WrapSpanExporter(name string, e sdktrace.SpanExporter) sdktrace.SpanExporter {
+	return &spanExporter{SpanExporter: e, stats: newExporterStats(name, "traces")}
+}
+
+func (e *spanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	err := e.SpanExporter.ExportSpans(ctx, spans)
+	e.stats.record(len(spans), err)
+	return err
+}
+
+type metricExporter struct {
+	metric.Exporter
+	stats *exporterStats
+}
+
+// WrapMetricExporter counts the exports and errors of the metric exporter.
+func WrapMetricExporter(name string, e metric.Exporter) metric.Exporter {
+	return &metricExporter{Exporter: e, stats: newExporterStats(name, "metrics")}
+}
+
+func (e *metricExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	err := e.Exporter.Export(ctx, rm)
+	n := 0
+	for _, sm := range rm.ScopeMetrics {
+		n += len(sm.Metrics)
+	}
+	e.stats.record(n, err)
+	return err
+}
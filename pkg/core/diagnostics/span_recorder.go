@@ -0,0 +1,130 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package diagnostics
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const default_recorded_spans = 100
+
+type spanSummary struct {
+	Name       string            `json:"name"`
+	Kind       string            `json:"kind"`
+	TraceId    string            `json:"trace_id"`
+	SpanId     string            `json:"span_id"`
+	ParentId   string            `json:"parent_span_id,omitempty"`
+	Scope      string            `json:"scope"`
+	Status     string            `json:"status"`
+	StartTime  time.Time         `json:"start_time"`
+	Duration   string            `json:"duration"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// SpanRecorder is a SpanProcessor keeping the most recently ended sampled
+// spans in a ring buffer.
+type SpanRecorder struct {
+	mu    sync.Mutex
+	spans []spanSummary
+	next  int
+	full  bool
+	ended atomic.Int64
+}
+
+var recorder *SpanRecorder
+
+// NewSpanRecorder creates the recorder served by the spans page, size <= 0
+// means the default size.
+func NewSpanRecorder(size int) *SpanRecorder {
+	if size <= 0 {
+		size = default_recorded_spans
+	}
+	recorder = &SpanRecorder{spans: make([]spanSummary, size)}
+	return recorder
+}
+
+func (r *SpanRecorder) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {}
+
+func (r *SpanRecorder) OnEnd(s sdktrace.ReadOnlySpan) {
+	if !s.SpanContext().IsSampled() {
+		return
+	}
+	r.ended.Add(1)
+	summary := spanSummary{
+		Name:      s.Name(),
+		Kind:      s.SpanKind().String(),
+		TraceId:   s.SpanContext().TraceID().String(),
+		SpanId:    s.SpanContext().SpanID().String(),
+		Scope:     s.InstrumentationScope().Name,
+		Status:    s.Status().Code.String(),
+		StartTime: s.StartTime(),
+		Duration:  s.EndTime().Sub(s.StartTime()).String(),
+	}
+	if s.Parent().IsValid() {
+		summary.ParentId = s.Parent().SpanID().String()
+	}
+	if attrs := s.Attributes(); len(attrs) > 0 {
+		summary.Attributes = make(map[string]string, len(attrs))
+		for _, attr := range attrs {
+			summary.Attributes[string(attr.Key)] = attr.Value.Emit()
+		}
+	}
+	r.mu.Lock()
+	r.spans[r.next] = summary
+	r.next = (r.next + 1) % len(r.spans)
+	if r.next == 0 {
+		r.full = true
+	}
+	r.mu.Unlock()
+}
+
+func (r *SpanRecorder) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+func (r *SpanRecorder) ForceFlush(ctx context.Context) error {
+	return nil
+}
+
+// Ended returns the number of sampled spans ended so far.
+func (r *SpanRecorder) Ended() int64 {
+	return r.ended.Load()
+}
+
+// Spans returns the recorded spans, the latest first.
+func (r *SpanRecorder) Spans() []spanSummary {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := r.next
+	if r.full {
+		n = len(r.spans)
+	}
+	result := make([]spanSummary, 0, n)
+	for i := 1; i <= n; i++ {
+		result = append(result, r.spans[(r.next-i+len(r.spans))%len(r.spans)])
+	}
+	return result
+}
+
+func spansPage() any {
+	if recorder == nil {
+		return []spanSummary{}
+	}
+	return recorder.Spans()
+}
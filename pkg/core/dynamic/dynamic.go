@@ -0,0 +1,174 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dynamic reloads the instrumentation switches, the http filter and the
+// sampling ratio while the process is running.
+package dynamic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/core/config"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/instrumenter"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileEnv points to a yaml file watched for Settings, e.g.
+//
+//	instrumentation:
+//	  disabled: [gin]
+//	  http_filter:
+//	    exclude:
+//	      - paths: [/health, /metrics]
+//	sampler:
+//	  ratio: 0.1
+const ConfigFileEnv = "OTEL_INSTRUMENTATION_DYNAMIC_CONFIG_FILE"
+
+// Settings is a snapshot of the dynamic configuration, an absent section
+// restores the value configured at startup.
+type Settings struct {
+	Instrumentation *config.Instrumentation `yaml:"instrumentation"`
+	Sampler         *SamplerSettings        `yaml:"sampler"`
+}
+
+type SamplerSettings struct {
+	// Ratio samples the root spans by trace id, the child spans follow their
+	// parent.
+	Ratio *float64 `yaml:"ratio"`
+}
+
+// Provider supplies the latest settings, e.g. from a local file or from a
+// configuration center.
+type Provider interface {
+	// Watch calls update with the current settings and then with every change
+	// until ctx is done.
+	Watch(ctx context.Context, update func(*Settings)) error
+}
+
+// Parse parses the yaml settings, unknown fields are rejected.
+func Parse(content []byte) (*Settings, error) {
+	s := &Settings{}
+	decoder := yaml.NewDecoder(strings.NewReader(string(content)))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(s); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid dynamic configuration: %w", err)
+	}
+	if s.Instrumentation != nil && s.Instrumentation.HttpFilter != nil {
+		if _, err := s.Instrumentation.HttpFilter.NewFilter(); err != nil {
+			return nil, fmt.Errorf("invalid dynamic configuration: %w", err)
+		}
+	}
+	if s.Sampler != nil && s.Sampler.Ratio != nil && (*s.Sampler.Ratio < 0 || *s.Sampler.Ratio > 1) {
+		return nil, fmt.Errorf("invalid dynamic configuration: sampler ratio %v is out of [0, 1]", *s.Sampler.Ratio)
+	}
+	return s, nil
+}
+
+var (
+	mu      sync.Mutex
+	base    = &Settings{}
+	current *Settings
+	sampler = NewSampler(nil)
+)
+
+// SetBase records the settings configured at startup, they are restored when
+// a section is absent from the dynamic settings.
+func SetBase(s *Settings) {
+	mu.Lock()
+	defer mu.Unlock()
+	if s == nil {
+		s = &Settings{}
+	}
+	base = s
+}
+
+// DynamicSampler returns the sampler whose ratio is switched by the settings,
+// it has to be installed in the TracerProvider.
+func DynamicSampler() *Sampler {
+	return sampler
+}
+
+// Apply switches the enablers, the http filter and the sampling ratio to the
+// settings at once.
+func Apply(s *Settings) {
+	mu.Lock()
+	defer mu.Unlock()
+	if s == nil {
+		s = &Settings{}
+	}
+	instrumentation := s.Instrumentation
+	if instrumentation == nil {
+		instrumentation = base.Instrumentation
+	}
+	if instrumentation != nil {
+		instrumenter.ConfigureInstrumentEnablers(instrumentation.DefaultEnabled, instrumentation.Overrides())
+	} else {
+		instrumenter.ConfigureInstrumentEnablers(nil, nil)
+	}
+
+	utils.SetHttpFilter(newHttpFilter(s.Instrumentation, base.Instrumentation))
+
+	samplerSettings := s.Sampler
+	if samplerSettings == nil {
+		samplerSettings = base.Sampler
+	}
+	if samplerSettings != nil && samplerSettings.Ratio != nil {
+		sampler.SetRatio(*samplerSettings.Ratio)
+	} else {
+		sampler.ResetRatio()
+	}
+	current = s
+}
+
+// newHttpFilter falls back to the filter of the startup settings and then to
+// the filter configured by env.
+func newHttpFilter(instrumentations ...*config.Instrumentation) *utils.HttpFilter {
+	for _, instrumentation := range instrumentations {
+		if instrumentation == nil || instrumentation.HttpFilter == nil {
+			continue
+		}
+		// the filters are validated when the settings are parsed
+		if f, err := instrumentation.HttpFilter.NewFilter(); err == nil {
+			return f
+		}
+	}
+	f, err := utils.HttpFilterFromEnv()
+	if err != nil {
+		log.Printf("failed to configure the http filter: %v", err)
+	}
+	return f
+}
+
+// Current returns the settings applied last, nil if none.
+func Current() *Settings {
+	mu.Lock()
+	defer mu.Unlock()
+	return current
+}
+
+// Start watches the provider in the background until ctx is done.
+func Start(ctx context.Context, p Provider) {
+	go func() {
+		if err := p.Watch(ctx, Apply); err != nil && ctx.Err() == nil {
+			log.Printf("failed to watch the dynamic configuration: %v", err)
+		}
+	}()
+}
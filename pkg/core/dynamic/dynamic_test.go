@@ -0,0 +1,128 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamic
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/instrumenter"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/utils"
+	"go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+func TestParse(t *testing.T) {
+	s, err := Parse([]byte("instrumentation:\n  disabled: [gin]\n  http_filter:\n    exclude:\n      - paths: [/health]\nsampler:\n  ratio: 0.25\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Instrumentation.Disabled[0] != "gin" || *s.Sampler.Ratio != 0.25 || s.Instrumentation.HttpFilter.Exclude[0].Paths[0] != "/health" {
+		t.Fatalf("unexpected settings %+v", s)
+	}
+	if s, err = Parse(nil); err != nil || s.Sampler != nil {
+		t.Fatalf("expected empty settings, got %+v, %v", s, err)
+	}
+	for _, content := range []string{"unknown: {}", "sampler:\n  ratio: 2", "instrumentation:\n  http_filter:\n    exclude:\n      - path_regexes: [\"(\"]"} {
+		if _, err = Parse([]byte(content)); err == nil {
+			t.Fatalf("expected error for %q", content)
+		}
+	}
+}
+
+func sampled(s trace.Sampler) bool {
+	traceID := oteltrace.TraceID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	return s.ShouldSample(trace.SamplingParameters{ParentContext: context.Background(), TraceID: traceID}).Decision == trace.RecordAndSample
+}
+
+func TestApply(t *testing.T) {
+	defer func() {
+		SetBase(nil)
+		Apply(nil)
+	}()
+	gin := instrumenter.RegisterInstrumentEnabler("dynamic-test-gin", true)
+	filter := utils.DefaultUrlFilter{}
+	health := &url.URL{Path: "/health"}
+	s := DynamicSampler()
+	s.SetBase(trace.AlwaysSample())
+	if !sampled(s) {
+		t.Fatal("expected the base sampler to sample")
+	}
+
+	settings, err := Parse([]byte("instrumentation:\n  disabled: [dynamic-test-gin]\n  http_filter:\n    exclude:\n      - paths: [/health]\nsampler:\n  ratio: 0\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	Apply(settings)
+	if gin.Enable() || !filter.FilterUrl(health) || sampled(s) {
+		t.Fatal("expected the settings to be applied")
+	}
+	if Current() != settings {
+		t.Fatal("expected the current settings to be recorded")
+	}
+
+	ratio := 0.0
+	SetBase(&Settings{Sampler: &SamplerSettings{Ratio: &ratio}})
+	Apply(&Settings{})
+	if !gin.Enable() || filter.FilterUrl(health) {
+		t.Fatal("expected the startup settings to be restored")
+	}
+	if sampled(s) {
+		t.Fatal("expected the startup sampling ratio to be restored")
+	}
+}
+
+func TestFileProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dynamic.yaml")
+	if err := os.WriteFile(path, []byte("sampler:\n  ratio: 0.5\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	updates := make(chan *Settings, 4)
+	p := &FileProvider{Path: path, Interval: 10 * time.Millisecond}
+	go p.Watch(ctx, func(s *Settings) { updates <- s })
+
+	s := <-updates
+	if *s.Sampler.Ratio != 0.5 {
+		t.Fatalf("unexpected settings %+v", s)
+	}
+	if err := os.WriteFile(path, []byte("sampler:\n  ratio: 0.25\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case s = <-updates:
+		if *s.Sampler.Ratio != 0.25 {
+			t.Fatalf("unexpected settings %+v", s)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the file to be reloaded")
+	}
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case s = <-updates:
+		if s.Sampler != nil {
+			t.Fatalf("expected empty settings, got %+v", s)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the removal to be reported")
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamic
+
+import (
+	"context"
+	"log"
+	"os"
+	"time"
+)
+
+const defaultPollInterval = 10 * time.Second
+
+// FileProvider polls a local yaml file for Settings, the file is reloaded when
+// its modification time or size changes. An invalid file is reported and the
+// settings applied last are kept.
+type FileProvider struct {
+	Path     string
+	Interval time.Duration
+}
+
+func NewFileProvider(path string) *FileProvider {
+	return &FileProvider{Path: path, Interval: defaultPollInterval}
+}
+
+func (f *FileProvider) Watch(ctx context.Context, update func(*Settings)) error {
+	interval := f.Interval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	var modTime time.Time
+	var size int64 = -1
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		info, err := os.Stat(f.Path)
+		switch {
+		case os.IsNotExist(err):
+			// a removed file restores the startup settings
+			if size != -1 {
+				update(&Settings{})
+				modTime, size = time.Time{}, -1
+			}
+		case err != nil:
+			log.Printf("failed to stat %s: %v", f.Path, err)
+		case !info.ModTime().Equal(modTime) || info.Size() != size:
+			modTime, size = info.ModTime(), info.Size()
+			if s, err := f.load(); err != nil {
+				log.Printf("failed to reload %s: %v", f.Path, err)
+			} else {
+				update(s)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (f *FileProvider) load() (*Settings, error) {
+	content, err := os.ReadFile(f.Path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(content)
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dynamic
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Sampler delegates to the sampler configured at startup until a sampling
+// ratio is set, the delegate is swapped atomically.
+type Sampler struct {
+	base     atomic.Pointer[samplerHolder]
+	delegate atomic.Pointer[samplerHolder]
+}
+
+type samplerHolder struct {
+	sampler trace.Sampler
+}
+
+// NewSampler returns a Sampler delegating to base, a nil base is resolved
+// from OTEL_TRACES_SAMPLER and OTEL_TRACES_SAMPLER_ARG like the SDK does.
+func NewSampler(base trace.Sampler) *Sampler {
+	s := &Sampler{}
+	s.SetBase(base)
+	return s
+}
+
+// SetBase replaces the sampler used when no ratio is set.
+func (s *Sampler) SetBase(base trace.Sampler) {
+	if base == nil {
+		base = samplerFromEnv()
+	}
+	s.base.Store(&samplerHolder{sampler: base})
+}
+
+// SetRatio samples the root spans by ratio, the child spans follow their
+// parent.
+func (s *Sampler) SetRatio(ratio float64) {
+	s.delegate.Store(&samplerHolder{sampler: trace.ParentBased(trace.TraceIDRatioBased(ratio))})
+}
+
+// ResetRatio restores the base sampler.
+func (s *Sampler) ResetRatio() {
+	s.delegate.Store(nil)
+}
+
+func (s *Sampler) current() trace.Sampler {
+	if h := s.delegate.Load(); h != nil {
+		return h.sampler
+	}
+	return s.base.Load().sampler
+}
+
+func (s *Sampler) ShouldSample(parameters trace.SamplingParameters) trace.SamplingResult {
+	return s.current().ShouldSample(parameters)
+}
+
+func (s *Sampler) Description() string {
+	return "DynamicSampler{" + s.current().Description() + "}"
+}
+
+// samplerFromEnv mirrors the sampler selection of the SDK, which is bypassed
+// once a sampler is passed to the TracerProvider.
+func samplerFromEnv() trace.Sampler {
+	ratio := 1.0
+	if arg, ok := os.LookupEnv("OTEL_TRACES_SAMPLER_ARG"); ok {
+		if r, err := strconv.ParseFloat(strings.TrimSpace(arg), 64); err == nil && r >= 0 && r <= 1 {
+			ratio = r
+		}
+	}
+	switch strings.ToLower(strings.TrimSpace(os.Getenv("OTEL_TRACES_SAMPLER"))) {
+	case "always_on":
+		return trace.AlwaysSample()
+	case "always_off":
+		return trace.NeverSample()
+	case "traceidratio":
+		return trace.TraceIDRatioBased(ratio)
+	case "parentbased_always_off":
+		return trace.ParentBased(trace.NeverSample())
+	case "parentbased_traceidratio":
+		return trace.ParentBased(trace.TraceIDRatioBased(ratio))
+	default:
+		return trace.ParentBased(trace.AlwaysSample())
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/trace"
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	mpb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// The file exporter writes one OTLP-JSON export request per line, which is
+// the same format as the collector's file exporter and can be replayed by the
+// otlpjsonfile receiver.
+// path of the traces file: OTEL_EXPORTER_FILE_TRACES_PATH
+// path of the metrics file: OTEL_EXPORTER_FILE_METRICS_PATH
+// rotate the file after it grows beyond N megabytes: OTEL_EXPORTER_FILE_MAX_SIZE_MB
+// number of rotated files to retain: OTEL_EXPORTER_FILE_MAX_BACKUPS
+const file_traces_path = "OTEL_EXPORTER_FILE_TRACES_PATH"
+const file_metrics_path = "OTEL_EXPORTER_FILE_METRICS_PATH"
+const file_max_size_mb = "OTEL_EXPORTER_FILE_MAX_SIZE_MB"
+const file_max_backups = "OTEL_EXPORTER_FILE_MAX_BACKUPS"
+
+const default_file_traces_path = "otel-traces.jsonl"
+const default_file_metrics_path = "otel-metrics.jsonl"
+const default_file_max_size_mb = 100
+const default_file_max_backups = 5
+
+type FileConfig struct {
+	Path       string
+	MaxSizeMB  int
+	MaxBackups int
+}
+
+// DefaultFileConfig returns the default file exporter configuration of the
+// given signal.
+func DefaultFileConfig(signal string) FileConfig {
+	cfg := FileConfig{
+		Path:       default_file_traces_path,
+		MaxSizeMB:  default_file_max_size_mb,
+		MaxBackups: default_file_max_backups,
+	}
+	if signal == SignalMetrics {
+		cfg.Path = default_file_metrics_path
+	}
+	return cfg
+}
+
+// FileConfigFromEnv reads the file exporter configuration of the given signal
+// from the environment variables.
+func FileConfigFromEnv(signal string) FileConfig {
+	cfg := DefaultFileConfig(signal)
+	pathEnv := file_traces_path
+	if signal == SignalMetrics {
+		pathEnv = file_metrics_path
+	}
+	if path := os.Getenv(pathEnv); path != "" {
+		cfg.Path = path
+	}
+	cfg.MaxSizeMB = intFromEnv(file_max_size_mb, cfg.MaxSizeMB)
+	cfg.MaxBackups = intFromEnv(file_max_backups, cfg.MaxBackups)
+	return cfg
+}
+
+func intFromEnv(env string, defaultValue int) int {
+	v, err := strconv.Atoi(strings.TrimSpace(os.Getenv(env)))
+	if err != nil || v < 0 {
+		return defaultValue
+	}
+	return v
+}
+
+func (c FileConfig) open() (*rotatingFile, error) {
+	return newRotatingFile(c.Path, int64(c.MaxSizeMB)*1024*1024, c.MaxBackups)
+}
+
+func writeProtoLine(f *rotatingFile, msg proto.Message) error {
+	b, err := marshalOtlpJson(msg)
+	if err != nil {
+		return err
+	}
+	return f.WriteLine(b)
+}
+
+type fileTraceClient struct {
+	file *rotatingFile
+}
+
+func (f *fileTraceClient) Start(ctx context.Context) error {
+	return nil
+}
+
+func (f *fileTraceClient) Stop(ctx context.Context) error {
+	return f.file.Close()
+}
+
+func (f *fileTraceClient) UploadTraces(ctx context.Context, protoSpans []*tracepb.ResourceSpans) error {
+	if len(protoSpans) == 0 {
+		return nil
+	}
+	return writeProtoLine(f.file, &coltracepb.ExportTraceServiceRequest{ResourceSpans: protoSpans})
+}
+
+// NewFileSpanExporter returns a span exporter which writes OTLP-JSON lines
+// to the configured file.
+func NewFileSpanExporter(ctx context.Context, cfg FileConfig) (trace.SpanExporter, error) {
+	f, err := cfg.open()
+	if err != nil {
+		return nil, err
+	}
+	return otlptrace.New(ctx, &fileTraceClient{file: f})
+}
+
+type fileMetricExporter struct {
+	file *rotatingFile
+}
+
+// NewFileMetricExporter returns a metric exporter which writes OTLP-JSON
+// lines to the configured file.
+func NewFileMetricExporter(cfg FileConfig) (metric.Exporter, error) {
+	f, err := cfg.open()
+	if err != nil {
+		return nil, err
+	}
+	return &fileMetricExporter{file: f}, nil
+}
+
+func (f *fileMetricExporter) Temporality(kind metric.InstrumentKind) metricdata.Temporality {
+	return metric.DefaultTemporalitySelector(kind)
+}
+
+func (f *fileMetricExporter) Aggregation(kind metric.InstrumentKind) metric.Aggregation {
+	return metric.DefaultAggregationSelector(kind)
+}
+
+func (f *fileMetricExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	if rm == nil {
+		return nil
+	}
+	return writeProtoLine(f.file, &colmetricpb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*mpb.ResourceMetrics{transformResourceMetrics(rm)},
+	})
+}
+
+func (f *fileMetricExporter) ForceFlush(ctx context.Context) error {
+	return f.file.Sync()
+}
+
+func (f *fileMetricExporter) Shutdown(ctx context.Context) error {
+	return f.file.Close()
+}
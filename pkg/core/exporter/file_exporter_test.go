@@ -0,0 +1,119 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func readLines(t *testing.T, path string) []string {
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	lines := make([]string, 0)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines
+}
+
+func TestFileConfigFromEnv(t *testing.T) {
+	t.Setenv(file_metrics_path, "/tmp/m.jsonl")
+	t.Setenv(file_max_size_mb, "1")
+	cfg := FileConfigFromEnv(SignalMetrics)
+	if cfg.Path != "/tmp/m.jsonl" || cfg.MaxSizeMB != 1 || cfg.MaxBackups != default_file_max_backups {
+		t.Fatalf("unexpected config %+v", cfg)
+	}
+	if FileConfigFromEnv(SignalTraces).Path != default_file_traces_path {
+		t.Fatal("traces should use the default path")
+	}
+}
+
+func TestFileSpanExporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+	ctx := context.Background()
+	exp, err := NewFileSpanExporter(ctx, FileConfig{Path: path, MaxSizeMB: 1, MaxBackups: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp))
+	_, span := tp.Tracer("test").Start(ctx, "file-span")
+	span.End()
+	if err = tp.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+	lines := readLines(t, path)
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 line, got %d", len(lines))
+	}
+	var req otlpJsonTraces
+	if err = json.Unmarshal([]byte(lines[0]), &req); err != nil {
+		t.Fatal(err)
+	}
+	got := req.ResourceSpans[0].ScopeSpans[0].Spans[0]
+	if got.Name != "file-span" || got.TraceId != span.SpanContext().TraceID().String() || got.Kind != 1 {
+		t.Fatalf("expected an OTLP/JSON line, got %s", lines[0])
+	}
+}
+
+func TestFileMetricExporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.jsonl")
+	ctx := context.Background()
+	exp, err := NewFileMetricExporter(FileConfig{Path: path, MaxSizeMB: 1, MaxBackups: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mp := metric.NewMeterProvider(metric.WithReader(metric.NewPeriodicReader(exp)))
+	counter, _ := mp.Meter("test").Int64Counter("file.counter")
+	counter.Add(ctx, 1)
+	if err = mp.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+	lines := readLines(t, path)
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 line, got %d", len(lines))
+	}
+	var req struct {
+		ResourceMetrics []struct {
+			ScopeMetrics []struct {
+				Metrics []struct {
+					Name string `json:"name"`
+					Sum  struct {
+						AggregationTemporality int `json:"aggregationTemporality"`
+					} `json:"sum"`
+				} `json:"metrics"`
+			} `json:"scopeMetrics"`
+		} `json:"resourceMetrics"`
+	}
+	if err = json.Unmarshal([]byte(lines[0]), &req); err != nil {
+		t.Fatalf("expected an OTLP/JSON line, got %s: %v", lines[0], err)
+	}
+	got := req.ResourceMetrics[0].ScopeMetrics[0].Metrics[0]
+	// AGGREGATION_TEMPORALITY_CUMULATIVE
+	if got.Name != "file.counter" || got.Sum.AggregationTemporality != 2 {
+		t.Fatalf("unexpected metric %s", lines[0])
+	}
+}
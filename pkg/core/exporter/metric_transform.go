@@ -0,0 +1,332 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	cpb "go.opentelemetry.io/proto/otlp/common/v1"
+	mpb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	rpb "go.opentelemetry.io/proto/otlp/resource/v1"
+)
+
+// The otlpmetric exporters keep their metricdata->proto transform internal,
+// so the exporters in this package which speak OTLP on their own(http/json,
+// file) need a copy of it.
+
+func transformResourceMetrics(rm *metricdata.ResourceMetrics) *mpb.ResourceMetrics {
+	out := &mpb.ResourceMetrics{
+		Resource:     &rpb.Resource{},
+		ScopeMetrics: make([]*mpb.ScopeMetrics, 0, len(rm.ScopeMetrics)),
+	}
+	if rm.Resource != nil {
+		out.Resource.Attributes = transformAttrIter(rm.Resource.Iter())
+		out.SchemaUrl = rm.Resource.SchemaURL()
+	}
+	for _, sm := range rm.ScopeMetrics {
+		ms := make([]*mpb.Metric, 0, len(sm.Metrics))
+		for _, m := range sm.Metrics {
+			pm, err := transformMetric(m)
+			if err != nil {
+				// drop the invalid metric but keep the others
+				continue
+			}
+			ms = append(ms, pm)
+		}
+		out.ScopeMetrics = append(out.ScopeMetrics, &mpb.ScopeMetrics{
+			Scope: &cpb.InstrumentationScope{
+				Name:       sm.Scope.Name,
+				Version:    sm.Scope.Version,
+				Attributes: transformAttrIter(sm.Scope.Attributes.Iter()),
+			},
+			Metrics:   ms,
+			SchemaUrl: sm.Scope.SchemaURL,
+		})
+	}
+	return out
+}
+
+func transformMetric(m metricdata.Metrics) (*mpb.Metric, error) {
+	out := &mpb.Metric{
+		Name:        m.Name,
+		Description: m.Description,
+		Unit:        m.Unit,
+	}
+	switch a := m.Data.(type) {
+	case metricdata.Gauge[int64]:
+		out.Data = transformGauge(a)
+	case metricdata.Gauge[float64]:
+		out.Data = transformGauge(a)
+	case metricdata.Sum[int64]:
+		out.Data = transformSum(a)
+	case metricdata.Sum[float64]:
+		out.Data = transformSum(a)
+	case metricdata.Histogram[int64]:
+		out.Data = transformHistogram(a)
+	case metricdata.Histogram[float64]:
+		out.Data = transformHistogram(a)
+	case metricdata.ExponentialHistogram[int64]:
+		out.Data = transformExponentialHistogram(a)
+	case metricdata.ExponentialHistogram[float64]:
+		out.Data = transformExponentialHistogram(a)
+	case metricdata.Summary:
+		out.Data = transformSummary(a)
+	default:
+		return nil, fmt.Errorf("unknown aggregation %T of metric %s", a, m.Name)
+	}
+	return out, nil
+}
+
+func transformGauge[N int64 | float64](g metricdata.Gauge[N]) *mpb.Metric_Gauge {
+	return &mpb.Metric_Gauge{
+		Gauge: &mpb.Gauge{DataPoints: transformDataPoints(g.DataPoints)},
+	}
+}
+
+func transformSum[N int64 | float64](s metricdata.Sum[N]) *mpb.Metric_Sum {
+	return &mpb.Metric_Sum{
+		Sum: &mpb.Sum{
+			AggregationTemporality: transformTemporality(s.Temporality),
+			IsMonotonic:            s.IsMonotonic,
+			DataPoints:             transformDataPoints(s.DataPoints),
+		},
+	}
+}
+
+func transformDataPoints[N int64 | float64](dps []metricdata.DataPoint[N]) []*mpb.NumberDataPoint {
+	out := make([]*mpb.NumberDataPoint, 0, len(dps))
+	for _, dp := range dps {
+		ndp := &mpb.NumberDataPoint{
+			Attributes:        transformAttrIter(dp.Attributes.Iter()),
+			StartTimeUnixNano: timeUnixNano(dp.StartTime),
+			TimeUnixNano:      timeUnixNano(dp.Time),
+			Exemplars:         transformExemplars(dp.Exemplars),
+		}
+		switch v := any(dp.Value).(type) {
+		case int64:
+			ndp.Value = &mpb.NumberDataPoint_AsInt{AsInt: v}
+		case float64:
+			ndp.Value = &mpb.NumberDataPoint_AsDouble{AsDouble: v}
+		}
+		out = append(out, ndp)
+	}
+	return out
+}
+
+func transformHistogram[N int64 | float64](h metricdata.Histogram[N]) *mpb.Metric_Histogram {
+	dps := make([]*mpb.HistogramDataPoint, 0, len(h.DataPoints))
+	for _, dp := range h.DataPoints {
+		sum := float64(dp.Sum)
+		hdp := &mpb.HistogramDataPoint{
+			Attributes:        transformAttrIter(dp.Attributes.Iter()),
+			StartTimeUnixNano: timeUnixNano(dp.StartTime),
+			TimeUnixNano:      timeUnixNano(dp.Time),
+			Count:             dp.Count,
+			Sum:               &sum,
+			BucketCounts:      dp.BucketCounts,
+			ExplicitBounds:    dp.Bounds,
+			Exemplars:         transformExemplars(dp.Exemplars),
+		}
+		if v, ok := dp.Min.Value(); ok {
+			min := float64(v)
+			hdp.Min = &min
+		}
+		if v, ok := dp.Max.Value(); ok {
+			max := float64(v)
+			hdp.Max = &max
+		}
+		dps = append(dps, hdp)
+	}
+	return &mpb.Metric_Histogram{
+		Histogram: &mpb.Histogram{
+			AggregationTemporality: transformTemporality(h.Temporality),
+			DataPoints:             dps,
+		},
+	}
+}
+
+func transformExponentialHistogram[N int64 | float64](h metricdata.ExponentialHistogram[N]) *mpb.Metric_ExponentialHistogram {
+	dps := make([]*mpb.ExponentialHistogramDataPoint, 0, len(h.DataPoints))
+	for _, dp := range h.DataPoints {
+		sum := float64(dp.Sum)
+		ehdp := &mpb.ExponentialHistogramDataPoint{
+			Attributes:        transformAttrIter(dp.Attributes.Iter()),
+			StartTimeUnixNano: timeUnixNano(dp.StartTime),
+			TimeUnixNano:      timeUnixNano(dp.Time),
+			Count:             dp.Count,
+			Sum:               &sum,
+			Scale:             dp.Scale,
+			ZeroCount:         dp.ZeroCount,
+			ZeroThreshold:     dp.ZeroThreshold,
+			Exemplars:         transformExemplars(dp.Exemplars),
+			Positive: &mpb.ExponentialHistogramDataPoint_Buckets{
+				Offset:       dp.PositiveBucket.Offset,
+				BucketCounts: dp.PositiveBucket.Counts,
+			},
+			Negative: &mpb.ExponentialHistogramDataPoint_Buckets{
+				Offset:       dp.NegativeBucket.Offset,
+				BucketCounts: dp.NegativeBucket.Counts,
+			},
+		}
+		if v, ok := dp.Min.Value(); ok {
+			min := float64(v)
+			ehdp.Min = &min
+		}
+		if v, ok := dp.Max.Value(); ok {
+			max := float64(v)
+			ehdp.Max = &max
+		}
+		dps = append(dps, ehdp)
+	}
+	return &mpb.Metric_ExponentialHistogram{
+		ExponentialHistogram: &mpb.ExponentialHistogram{
+			AggregationTemporality: transformTemporality(h.Temporality),
+			DataPoints:             dps,
+		},
+	}
+}
+
+func transformSummary(s metricdata.Summary) *mpb.Metric_Summary {
+	dps := make([]*mpb.SummaryDataPoint, 0, len(s.DataPoints))
+	for _, dp := range s.DataPoints {
+		qvs := make([]*mpb.SummaryDataPoint_ValueAtQuantile, 0, len(dp.QuantileValues))
+		for _, q := range dp.QuantileValues {
+			qvs = append(qvs, &mpb.SummaryDataPoint_ValueAtQuantile{
+				Quantile: q.Quantile,
+				Value:    q.Value,
+			})
+		}
+		dps = append(dps, &mpb.SummaryDataPoint{
+			Attributes:        transformAttrIter(dp.Attributes.Iter()),
+			StartTimeUnixNano: timeUnixNano(dp.StartTime),
+			TimeUnixNano:      timeUnixNano(dp.Time),
+			Count:             dp.Count,
+			Sum:               dp.Sum,
+			QuantileValues:    qvs,
+		})
+	}
+	return &mpb.Metric_Summary{Summary: &mpb.Summary{DataPoints: dps}}
+}
+
+func transformExemplars[N int64 | float64](exemplars []metricdata.Exemplar[N]) []*mpb.Exemplar {
+	if len(exemplars) == 0 {
+		return nil
+	}
+	out := make([]*mpb.Exemplar, 0, len(exemplars))
+	for _, e := range exemplars {
+		pe := &mpb.Exemplar{
+			FilteredAttributes: transformKeyValues(e.FilteredAttributes),
+			TimeUnixNano:       timeUnixNano(e.Time),
+			SpanId:             e.SpanID,
+			TraceId:            e.TraceID,
+		}
+		switch v := any(e.Value).(type) {
+		case int64:
+			pe.Value = &mpb.Exemplar_AsInt{AsInt: v}
+		case float64:
+			pe.Value = &mpb.Exemplar_AsDouble{AsDouble: v}
+		}
+		out = append(out, pe)
+	}
+	return out
+}
+
+func transformTemporality(t metricdata.Temporality) mpb.AggregationTemporality {
+	switch t {
+	case metricdata.DeltaTemporality:
+		return mpb.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA
+	case metricdata.CumulativeTemporality:
+		return mpb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE
+	default:
+		return mpb.AggregationTemporality_AGGREGATION_TEMPORALITY_UNSPECIFIED
+	}
+}
+
+func timeUnixNano(t time.Time) uint64 {
+	if t.IsZero() || t.UnixNano() < 0 {
+		return 0
+	}
+	return uint64(t.UnixNano())
+}
+
+func transformAttrIter(iter attribute.Iterator) []*cpb.KeyValue {
+	if iter.Len() == 0 {
+		return nil
+	}
+	out := make([]*cpb.KeyValue, 0, iter.Len())
+	for iter.Next() {
+		out = append(out, transformKeyValue(iter.Attribute()))
+	}
+	return out
+}
+
+func transformKeyValues(attrs []attribute.KeyValue) []*cpb.KeyValue {
+	if len(attrs) == 0 {
+		return nil
+	}
+	out := make([]*cpb.KeyValue, 0, len(attrs))
+	for _, kv := range attrs {
+		out = append(out, transformKeyValue(kv))
+	}
+	return out
+}
+
+func transformKeyValue(kv attribute.KeyValue) *cpb.KeyValue {
+	return &cpb.KeyValue{Key: string(kv.Key), Value: transformValue(kv.Value)}
+}
+
+func transformValue(v attribute.Value) *cpb.AnyValue {
+	av := new(cpb.AnyValue)
+	switch v.Type() {
+	case attribute.BOOL:
+		av.Value = &cpb.AnyValue_BoolValue{BoolValue: v.AsBool()}
+	case attribute.INT64:
+		av.Value = &cpb.AnyValue_IntValue{IntValue: v.AsInt64()}
+	case attribute.FLOAT64:
+		av.Value = &cpb.AnyValue_DoubleValue{DoubleValue: v.AsFloat64()}
+	case attribute.STRING:
+		av.Value = &cpb.AnyValue_StringValue{StringValue: v.AsString()}
+	case attribute.BOOLSLICE:
+		av.Value = arrayValue(v.AsBoolSlice(), func(b bool) *cpb.AnyValue {
+			return &cpb.AnyValue{Value: &cpb.AnyValue_BoolValue{BoolValue: b}}
+		})
+	case attribute.INT64SLICE:
+		av.Value = arrayValue(v.AsInt64Slice(), func(i int64) *cpb.AnyValue {
+			return &cpb.AnyValue{Value: &cpb.AnyValue_IntValue{IntValue: i}}
+		})
+	case attribute.FLOAT64SLICE:
+		av.Value = arrayValue(v.AsFloat64Slice(), func(f float64) *cpb.AnyValue {
+			return &cpb.AnyValue{Value: &cpb.AnyValue_DoubleValue{DoubleValue: f}}
+		})
+	case attribute.STRINGSLICE:
+		av.Value = arrayValue(v.AsStringSlice(), func(s string) *cpb.AnyValue {
+			return &cpb.AnyValue{Value: &cpb.AnyValue_StringValue{StringValue: s}}
+		})
+	default:
+		av.Value = &cpb.AnyValue_StringValue{StringValue: "INVALID"}
+	}
+	return av
+}
+
+func arrayValue[T any](vals []T, convert func(T) *cpb.AnyValue) *cpb.AnyValue_ArrayValue {
+	values := make([]*cpb.AnyValue, 0, len(vals))
+	for _, v := range vals {
+		values = append(values, convert(v))
+	}
+	return &cpb.AnyValue_ArrayValue{ArrayValue: &cpb.ArrayValue{Values: values}}
+}
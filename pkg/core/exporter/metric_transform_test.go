@@ -0,0 +1,71 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+)
+
+func TestTransformResourceMetrics(t *testing.T) {
+	reader := metric.NewManualReader()
+	res := resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName("my-service"))
+	mp := metric.NewMeterProvider(metric.WithResource(res), metric.WithReader(reader))
+	meter := mp.Meter("test-meter")
+	ctx := context.Background()
+	counter, _ := meter.Int64Counter("test.counter")
+	counter.Add(ctx, 3)
+	histogram, _ := meter.Float64Histogram("test.histogram")
+	histogram.Record(ctx, 1.5)
+	rm := &metricdata.ResourceMetrics{}
+	if err := reader.Collect(ctx, rm); err != nil {
+		t.Fatal(err)
+	}
+	pm := transformResourceMetrics(rm)
+	if pm.SchemaUrl != semconv.SchemaURL {
+		t.Fatalf("unexpected schema url %s", pm.SchemaUrl)
+	}
+	if len(pm.Resource.Attributes) == 0 {
+		t.Fatal("resource attributes should be kept")
+	}
+	metrics := pm.ScopeMetrics[0].Metrics
+	if len(metrics) != 2 {
+		t.Fatalf("expected 2 metrics, got %d", len(metrics))
+	}
+	if metrics[0].GetSum().GetDataPoints()[0].GetAsInt() != 3 {
+		t.Fatal("wrong counter value")
+	}
+	if metrics[1].GetHistogram().GetDataPoints()[0].GetSum() != 1.5 {
+		t.Fatal("wrong histogram sum")
+	}
+}
+
+func TestTransformValue(t *testing.T) {
+	if transformValue(attribute.StringSliceValue([]string{"a", "b"})).GetArrayValue().GetValues()[1].GetStringValue() != "b" {
+		t.Fatal("wrong string slice value")
+	}
+	if !transformValue(attribute.BoolValue(true)).GetBoolValue() {
+		t.Fatal("wrong bool value")
+	}
+	if transformValue(attribute.Int64Value(7)).GetIntValue() != 7 {
+		t.Fatal("wrong int value")
+	}
+}
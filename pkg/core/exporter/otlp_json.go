@@ -0,0 +1,84 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// OTLP/JSON differs from the canonical protobuf json mapping, the trace and
+// span ids are lowercase hex instead of base64 and the enums are integers.
+var otlpJsonOptions = protojson.MarshalOptions{UseEnumNumbers: true}
+
+// the bytes fields holding trace or span ids, they are named the same in the
+// spans, the links, the log records and the exemplars
+var otlpIdFields = map[string]bool{
+	"traceId":      true,
+	"spanId":       true,
+	"parentSpanId": true,
+}
+
+// marshalOtlpJson encodes the export request as OTLP/JSON.
+func marshalOtlpJson(msg proto.Message) ([]byte, error) {
+	b, err := otlpJsonOptions.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	// keep the numbers as they are, e.g. the doubles of the data points
+	dec.UseNumber()
+	var doc any
+	if err = dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	if err = hexIds(doc); err != nil {
+		return nil, err
+	}
+	return json.Marshal(doc)
+}
+
+// hexIds rewrites the base64 ids in place.
+func hexIds(v any) error {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if s, ok := field.(string); ok && otlpIdFields[key] {
+				id, err := base64.StdEncoding.DecodeString(s)
+				if err != nil {
+					return fmt.Errorf("invalid %s %q: %v", key, s, err)
+				}
+				v[key] = hex.EncodeToString(id)
+				continue
+			}
+			if err := hexIds(field); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for _, item := range v {
+			if err := hexIds(item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
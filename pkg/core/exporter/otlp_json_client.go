@@ -0,0 +1,234 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	mpb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	SignalTraces  = "TRACES"
+	SignalMetrics = "METRICS"
+)
+
+const default_otlp_http_endpoint = "http://localhost:4318"
+const default_otlp_timeout = 10 * time.Second
+
+// The net/http client rule skips requests whose user agent starts with
+// "OTel OTLP Exporter Go", so we must keep that prefix to avoid tracing
+// our own exports.
+const otlp_json_user_agent = "OTel OTLP Exporter Go (http/json)"
+
+var signalPaths = map[string]string{
+	SignalTraces:  "/v1/traces",
+	SignalMetrics: "/v1/metrics",
+}
+
+type HttpJsonConfig struct {
+	Endpoint string
+	Headers  map[string]string
+	Timeout  time.Duration
+}
+
+// HttpJsonConfigFromEnv resolves the endpoint, headers and timeout of the
+// given signal from the OTEL_EXPORTER_OTLP_* environment variables.
+func HttpJsonConfigFromEnv(signal string) HttpJsonConfig {
+	return HttpJsonConfig{
+		Endpoint: resolveEndpoint(signal),
+		Headers:  ParseHeaders(os.Getenv("OTEL_EXPORTER_OTLP_HEADERS"), os.Getenv("OTEL_EXPORTER_OTLP_"+signal+"_HEADERS")),
+		Timeout:  parseTimeout(otlpEnv(signal, "TIMEOUT")),
+	}
+}
+
+// httpJsonClient sends OTLP requests encoded as JSON over HTTP.
+type httpJsonClient struct {
+	endpoint string
+	headers  map[string]string
+	client   *http.Client
+}
+
+func newHttpJsonClient(cfg HttpJsonConfig) *httpJsonClient {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = default_otlp_timeout
+	}
+	return &httpJsonClient{
+		endpoint: cfg.Endpoint,
+		headers:  cfg.Headers,
+		client:   &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+func (c *httpJsonClient) upload(ctx context.Context, msg proto.Message) error {
+	body, err := marshalOtlpJson(msg)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", otlp_json_user_agent)
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to send otlp json to %s: %s", c.endpoint, resp.Status)
+	}
+	return nil
+}
+
+// DefaultHttpEndpoint returns the default otlp http endpoint of the signal.
+func DefaultHttpEndpoint(signal string) string {
+	return default_otlp_http_endpoint + signalPaths[signal]
+}
+
+func otlpEnv(signal, name string) string {
+	if v := os.Getenv("OTEL_EXPORTER_OTLP_" + signal + "_" + name); v != "" {
+		return v
+	}
+	return os.Getenv("OTEL_EXPORTER_OTLP_" + name)
+}
+
+// resolveEndpoint follows the spec: the signal specific endpoint is used as
+// is, while the generic one is suffixed with the signal path.
+func resolveEndpoint(signal string) string {
+	if v := os.Getenv("OTEL_EXPORTER_OTLP_" + signal + "_ENDPOINT"); v != "" {
+		return v
+	}
+	base := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	if base == "" {
+		base = default_otlp_http_endpoint
+	}
+	return strings.TrimSuffix(base, "/") + signalPaths[signal]
+}
+
+// ParseHeaders parses the comma-separated key=value pairs used by
+// OTEL_EXPORTER_OTLP_HEADERS, later values override earlier ones.
+func ParseHeaders(values ...string) map[string]string {
+	headers := make(map[string]string)
+	for _, value := range values {
+		for _, pair := range strings.Split(value, ",") {
+			k, v, found := strings.Cut(pair, "=")
+			if !found {
+				continue
+			}
+			k, errK := url.PathUnescape(strings.TrimSpace(k))
+			v, errV := url.PathUnescape(strings.TrimSpace(v))
+			if errK != nil || errV != nil || k == "" {
+				continue
+			}
+			headers[k] = v
+		}
+	}
+	return headers
+}
+
+func parseTimeout(value string) time.Duration {
+	if value == "" {
+		return default_otlp_timeout
+	}
+	ms, err := strconv.Atoi(value)
+	if err != nil || ms < 0 {
+		return default_otlp_timeout
+	}
+	return time.Duration(ms) * time.Millisecond
+}
+
+type traceJsonClient struct {
+	*httpJsonClient
+}
+
+// NewTraceHttpJsonClient returns an otlptrace.Client which uses the
+// http/json OTLP protocol.
+func NewTraceHttpJsonClient(cfg HttpJsonConfig) otlptrace.Client {
+	return &traceJsonClient{newHttpJsonClient(cfg)}
+}
+
+func (t *traceJsonClient) Start(ctx context.Context) error {
+	return nil
+}
+
+func (t *traceJsonClient) Stop(ctx context.Context) error {
+	t.client.CloseIdleConnections()
+	return nil
+}
+
+func (t *traceJsonClient) UploadTraces(ctx context.Context, protoSpans []*tracepb.ResourceSpans) error {
+	if len(protoSpans) == 0 {
+		return nil
+	}
+	return t.upload(ctx, &coltracepb.ExportTraceServiceRequest{ResourceSpans: protoSpans})
+}
+
+type metricJsonExporter struct {
+	*httpJsonClient
+}
+
+// NewMetricHttpJsonExporter returns a metric.Exporter which uses the
+// http/json OTLP protocol.
+func NewMetricHttpJsonExporter(cfg HttpJsonConfig) metric.Exporter {
+	return &metricJsonExporter{newHttpJsonClient(cfg)}
+}
+
+func (m *metricJsonExporter) Temporality(kind metric.InstrumentKind) metricdata.Temporality {
+	return metric.DefaultTemporalitySelector(kind)
+}
+
+func (m *metricJsonExporter) Aggregation(kind metric.InstrumentKind) metric.Aggregation {
+	return metric.DefaultAggregationSelector(kind)
+}
+
+func (m *metricJsonExporter) Export(ctx context.Context, rm *metricdata.ResourceMetrics) error {
+	if rm == nil {
+		return nil
+	}
+	return m.upload(ctx, &colmetricpb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*mpb.ResourceMetrics{transformResourceMetrics(rm)},
+	})
+}
+
+func (m *metricJsonExporter) ForceFlush(ctx context.Context) error {
+	return ctx.Err()
+}
+
+func (m *metricJsonExporter) Shutdown(ctx context.Context) error {
+	m.client.CloseIdleConnections()
+	return ctx.Err()
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestResolveEndpoint(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://collector:4318/")
+	if e := resolveEndpoint(SignalTraces); e != "http://collector:4318/v1/traces" {
+		t.Fatalf("unexpected endpoint %s", e)
+	}
+	t.Setenv("OTEL_EXPORTER_OTLP_METRICS_ENDPOINT", "http://metrics:4318/custom")
+	if e := resolveEndpoint(SignalMetrics); e != "http://metrics:4318/custom" {
+		t.Fatalf("unexpected endpoint %s", e)
+	}
+}
+
+func TestParseHeaders(t *testing.T) {
+	headers := ParseHeaders("api-key=abc,x-tenant=a%20b", "api-key=def,invalid")
+	if headers["api-key"] != "def" {
+		t.Fatalf("signal headers should override generic ones, got %s", headers["api-key"])
+	}
+	if headers["x-tenant"] != "a b" {
+		t.Fatalf("header value should be unescaped, got %s", headers["x-tenant"])
+	}
+	if len(headers) != 2 {
+		t.Fatalf("invalid pair should be skipped, got %v", headers)
+	}
+}
+
+func TestParseTimeout(t *testing.T) {
+	if parseTimeout("") != default_otlp_timeout || parseTimeout("abc") != default_otlp_timeout {
+		t.Fatal("invalid timeout should fall back to default")
+	}
+	if parseTimeout("500") != 500*time.Millisecond {
+		t.Fatal("timeout should be in milliseconds")
+	}
+}
+
+// otlpJsonSpan is the raw OTLP/JSON of a span, the ids must be hex and the
+// enums integers
+type otlpJsonSpan struct {
+	TraceId      string `json:"traceId"`
+	SpanId       string `json:"spanId"`
+	ParentSpanId string `json:"parentSpanId"`
+	Name         string `json:"name"`
+	Kind         int    `json:"kind"`
+	Status       struct {
+		Code int `json:"code"`
+	} `json:"status"`
+}
+
+type otlpJsonTraces struct {
+	ResourceSpans []struct {
+		ScopeSpans []struct {
+			Spans []otlpJsonSpan `json:"spans"`
+		} `json:"scopeSpans"`
+	} `json:"resourceSpans"`
+}
+
+func TestTraceHttpJsonClient(t *testing.T) {
+	received := make(chan []byte, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected content type %s", r.Header.Get("Content-Type"))
+		}
+		if !strings.HasPrefix(r.UserAgent(), "OTel OTLP Exporter Go") {
+			t.Errorf("unexpected user agent %s", r.UserAgent())
+		}
+		body, _ := io.ReadAll(r.Body)
+		received <- body
+	}))
+	defer server.Close()
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", server.URL+"/v1/traces")
+	ctx := context.Background()
+	exp, err := otlptrace.New(ctx, NewTraceHttpJsonClient(HttpJsonConfigFromEnv(SignalTraces)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp))
+	defer func() { _ = tp.Shutdown(ctx) }()
+	parentCtx, parent := tp.Tracer("test").Start(ctx, "parent")
+	_, span := tp.Tracer("test").Start(parentCtx, "json-span", trace.WithSpanKind(trace.SpanKindServer))
+	span.SetStatus(codes.Error, "failed")
+	span.End()
+
+	body := <-received
+	var req otlpJsonTraces
+	if err = json.Unmarshal(body, &req); err != nil {
+		t.Fatal(err)
+	}
+	got := req.ResourceSpans[0].ScopeSpans[0].Spans[0]
+	sc := span.SpanContext()
+	if got.Name != "json-span" || got.TraceId != sc.TraceID().String() || got.SpanId != sc.SpanID().String() ||
+		got.ParentSpanId != parent.SpanContext().SpanID().String() {
+		t.Fatalf("expected hex ids, got %s", body)
+	}
+	// SPAN_KIND_SERVER and STATUS_CODE_ERROR
+	if got.Kind != 2 || got.Status.Code != 2 {
+		t.Fatalf("expected integer enums, got %s", body)
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// rotatingFile appends lines to a file and rotates it once it grows beyond
+// maxSize bytes. Rotated files are renamed to path.1, path.2, ... and at most
+// maxBackups of them are retained.
+type rotatingFile struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+	closed     bool
+}
+
+func newRotatingFile(path string, maxSize int64, maxBackups int) (*rotatingFile, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	r := &rotatingFile{path: path, maxSize: maxSize, maxBackups: maxBackups}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *rotatingFile) open() error {
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	r.file = f
+	r.size = info.Size()
+	return nil
+}
+
+// rotate starts a new file, the current one is reopened when the rotation
+// fails so that the lines keep being written.
+func (r *rotatingFile) rotate() error {
+	err := r.file.Close()
+	if err == nil {
+		err = r.shiftBackups()
+	}
+	if err != nil {
+		if openErr := r.open(); openErr != nil {
+			r.file = nil
+			return errors.Join(err, openErr)
+		}
+		return err
+	}
+	if err = r.open(); err != nil {
+		r.file = nil
+	}
+	return err
+}
+
+// shiftBackups renames the closed file to path.1, the older backups are
+// shifted by one.
+func (r *rotatingFile) shiftBackups() error {
+	if r.maxBackups <= 0 {
+		// nothing to retain, just start over
+		if err := os.Remove(r.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	_ = os.Remove(r.backupName(r.maxBackups))
+	for i := r.maxBackups - 1; i >= 1; i-- {
+		if err := os.Rename(r.backupName(i), r.backupName(i+1)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return os.Rename(r.path, r.backupName(1))
+}
+
+func (r *rotatingFile) backupName(i int) string {
+	return fmt.Sprintf("%s.%d", r.path, i)
+}
+
+// WriteLine writes b followed by a line break.
+func (r *rotatingFile) WriteLine(b []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return errors.New("file exporter is already closed")
+	}
+	if r.file == nil {
+		// the file could not be reopened after a failed rotation
+		if err := r.open(); err != nil {
+			return err
+		}
+	}
+	n := int64(len(b) + 1)
+	if r.maxSize > 0 && r.size > 0 && r.size+n > r.maxSize {
+		if err := r.rotate(); err != nil {
+			if r.file == nil {
+				return err
+			}
+			// the line goes to the current file, the rotation is retried
+			// with the next one
+			log.Printf("failed to rotate %s: %v", r.path, err)
+		}
+	}
+	written, err := r.file.Write(append(b, '\n'))
+	r.size += int64(written)
+	return err
+}
+
+func (r *rotatingFile) Sync() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed || r.file == nil {
+		return nil
+	}
+	return r.file.Sync()
+}
+
+func (r *rotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	if r.file == nil {
+		return nil
+	}
+	if err := r.file.Sync(); err != nil {
+		_ = r.file.Close()
+		return err
+	}
+	return r.file.Close()
+}
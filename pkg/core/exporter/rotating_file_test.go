@@ -0,0 +1,116 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "out.jsonl")
+	f, err := newRotatingFile(path, 9, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{"aaaa", "bbbb", "cccc", "dddd"} {
+		if err = f.WriteLine([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	// closing twice is allowed
+	if err = f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{
+		path:        "dddd\n",
+		path + ".1": "cccc\n",
+		path + ".2": "bbbb\n",
+	}
+	for p, content := range expected {
+		b, err := os.ReadFile(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != content {
+			t.Fatalf("unexpected content of %s: %q", p, string(b))
+		}
+	}
+	if _, err = os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Fatal("only 2 backups should be retained")
+	}
+	if f.WriteLine([]byte("eeee")) == nil {
+		t.Fatal("write after close should fail")
+	}
+}
+
+func TestRotatingFileWithoutBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.jsonl")
+	f, err := newRotatingFile(path, 6, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = f.WriteLine([]byte("aaaa"))
+	_ = f.WriteLine([]byte("bbbb"))
+	_ = f.Close()
+	b, _ := os.ReadFile(path)
+	if string(b) != "bbbb\n" {
+		t.Fatalf("unexpected content %q", string(b))
+	}
+}
+
+func TestRotatingFileRenameFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.jsonl")
+	// a non-empty directory can not be replaced by the rotated file
+	if err := os.MkdirAll(filepath.Join(path+".1", "keep"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	f, err := newRotatingFile(path, 6, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{"aaaa", "bbbb"} {
+		if err = f.WriteLine([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the rotation succeeds once the backup name is free
+	if err = os.RemoveAll(path + ".1"); err != nil {
+		t.Fatal(err)
+	}
+	if err = f.WriteLine([]byte("cccc")); err != nil {
+		t.Fatal(err)
+	}
+	if err = f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{
+		path:        "cccc\n",
+		path + ".1": "aaaa\nbbbb\n",
+	}
+	for p, content := range expected {
+		b, err := os.ReadFile(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != content {
+			t.Fatalf("unexpected content of %s: %q", p, string(b))
+		}
+	}
+}
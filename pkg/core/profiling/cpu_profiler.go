@@ -0,0 +1,212 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profiling
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime/pprof"
+	"sync"
+	"time"
+)
+
+const (
+	// directory the cpu profiles are written to, the collector is off when
+	// it is empty. The collector holds the cpu profile of the process, so
+	// /debug/pprof/profile and pprof.StartCPUProfile fail while it is on.
+	DirEnv = "OTEL_INSTRUMENTATION_PPROF_CPU_PROFILE_DIR"
+	// length of every profile, e.g. 10s
+	WindowEnv = "OTEL_INSTRUMENTATION_PPROF_CPU_PROFILE_WINDOW"
+	// local root spans running longer than it get the profile ids, e.g. 1s
+	ThresholdEnv = "OTEL_INSTRUMENTATION_PPROF_LONG_SPAN_THRESHOLD"
+)
+
+const (
+	defaultWindow    = 10 * time.Second
+	defaultThreshold = time.Second
+	// number of the profiles kept on disk, the older ones are removed
+	keptProfiles = 60
+	fileSuffix   = ".pprof"
+)
+
+type window struct {
+	id    string
+	start time.Time
+	// zero while the profile is running
+	end time.Time
+}
+
+// CPUProfiler writes the cpu profile of the process to a new file every
+// window, the file is named after the profile id.
+type CPUProfiler struct {
+	dir       string
+	window    time.Duration
+	threshold time.Duration
+	// file of the running profile, nil when the cpu profile is taken by
+	// someone else
+	file *os.File
+
+	mu      sync.Mutex
+	windows []window
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+func NewCPUProfiler(dir string, window, threshold time.Duration) *CPUProfiler {
+	if window <= 0 {
+		window = defaultWindow
+	}
+	if threshold <= 0 {
+		threshold = defaultThreshold
+	}
+	return &CPUProfiler{dir: dir, window: window, threshold: threshold}
+}
+
+// NewCPUProfilerFromEnv returns nil when no profile directory is configured.
+func NewCPUProfilerFromEnv() *CPUProfiler {
+	dir := os.Getenv(DirEnv)
+	if dir == "" {
+		return nil
+	}
+	return NewCPUProfiler(dir, durationEnv(WindowEnv), durationEnv(ThresholdEnv))
+}
+
+func durationEnv(name string) time.Duration {
+	val := os.Getenv(name)
+	if val == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(val)
+	if err != nil {
+		log.Printf("invalid %s %q: %v", name, val, err)
+		return 0
+	}
+	return d
+}
+
+// Start fails when the cpu profile is already taken by someone else.
+func (p *CPUProfiler) Start() error {
+	if err := os.MkdirAll(p.dir, 0o755); err != nil {
+		return err
+	}
+	if err := p.startWindow(); err != nil {
+		return err
+	}
+	p.stop = make(chan struct{})
+	p.done = make(chan struct{})
+	go p.run()
+	return nil
+}
+
+// Stop ends the running profile and waits for it to be written.
+func (p *CPUProfiler) Stop() {
+	if p.stop == nil {
+		return
+	}
+	close(p.stop)
+	<-p.done
+	p.stop = nil
+}
+
+func (p *CPUProfiler) run() {
+	defer close(p.done)
+	ticker := time.NewTicker(p.window)
+	defer ticker.Stop()
+	failed := false
+	for {
+		select {
+		case <-p.stop:
+			p.endWindow()
+			return
+		case <-ticker.C:
+			p.endWindow()
+			// someone else may take the cpu profile between two windows,
+			// it is retried on the next tick
+			if err := p.startWindow(); err != nil {
+				if !failed {
+					log.Printf("failed to start the cpu profile, retrying every %v: %v", p.window, err)
+				}
+				failed = true
+			} else {
+				failed = false
+			}
+		}
+	}
+}
+
+func (p *CPUProfiler) startWindow() error {
+	id := newProfileId()
+	f, err := os.Create(filepath.Join(p.dir, id+fileSuffix))
+	if err != nil {
+		return err
+	}
+	if err = pprof.StartCPUProfile(f); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	p.file = f
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.windows = append(p.windows, window{id: id, start: time.Now()})
+	if len(p.windows) > keptProfiles {
+		os.Remove(filepath.Join(p.dir, p.windows[0].id+fileSuffix))
+		p.windows = p.windows[1:]
+	}
+	return nil
+}
+
+func (p *CPUProfiler) endWindow() {
+	if p.file == nil {
+		return
+	}
+	pprof.StopCPUProfile()
+	if err := p.file.Close(); err != nil {
+		log.Printf("failed to write the cpu profile: %v", err)
+	}
+	p.file = nil
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if n := len(p.windows); n > 0 {
+		p.windows[n-1].end = time.Now()
+	}
+}
+
+func (p *CPUProfiler) Threshold() time.Duration {
+	return p.threshold
+}
+
+// ProfileIds returns the ids of the kept profiles overlapping [start, end].
+func (p *CPUProfiler) ProfileIds(start, end time.Time) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var ids []string
+	for _, w := range p.windows {
+		if w.start.After(end) || (!w.end.IsZero() && w.end.Before(start)) {
+			continue
+		}
+		ids = append(ids, w.id)
+	}
+	return ids
+}
+
+func newProfileId() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
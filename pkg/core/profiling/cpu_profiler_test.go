@@ -0,0 +1,88 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profiling
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime/pprof"
+	"testing"
+	"time"
+)
+
+func TestProfilerFromEnv(t *testing.T) {
+	t.Setenv(DirEnv, "")
+	if NewCPUProfilerFromEnv() != nil {
+		t.Fatal("expected no profiler without a directory")
+	}
+	t.Setenv(DirEnv, t.TempDir())
+	t.Setenv(ThresholdEnv, "3s")
+	t.Setenv(WindowEnv, "bad")
+	p := NewCPUProfilerFromEnv()
+	if p.Threshold() != 3*time.Second || p.window != defaultWindow {
+		t.Fatalf("unexpected threshold %v and window %v", p.Threshold(), p.window)
+	}
+}
+
+func TestCPUProfiler(t *testing.T) {
+	dir := t.TempDir()
+	p := NewCPUProfiler(dir, 20*time.Millisecond, 0)
+	start := time.Now()
+	if err := p.Start(); err != nil {
+		t.Fatal(err)
+	}
+	// the cpu profile is taken by the profiler
+	if err := NewCPUProfiler(t.TempDir(), 0, 0).Start(); err == nil {
+		t.Fatal("expected a second profiler to fail")
+	}
+	time.Sleep(70 * time.Millisecond)
+	p.Stop()
+
+	ids := p.ProfileIds(start, time.Now())
+	if len(ids) < 2 {
+		t.Fatalf("expected several profiles, got %v", ids)
+	}
+	for _, id := range ids {
+		info, err := os.Stat(filepath.Join(dir, id+fileSuffix))
+		if err != nil || info.Size() == 0 {
+			t.Fatalf("expected the profile %s to be written: %v", id, err)
+		}
+	}
+	if ids := p.ProfileIds(start.Add(-time.Hour), start.Add(-time.Minute)); len(ids) != 0 {
+		t.Fatalf("expected no profiles before the start, got %v", ids)
+	}
+}
+
+func TestCPUProfilerRetries(t *testing.T) {
+	// someone else holds the cpu profile when the next window starts
+	var buf bytes.Buffer
+	if err := pprof.StartCPUProfile(&buf); err != nil {
+		t.Fatal(err)
+	}
+	p := NewCPUProfiler(t.TempDir(), 20*time.Millisecond, 0)
+	p.stop = make(chan struct{})
+	p.done = make(chan struct{})
+	go p.run()
+	defer p.Stop()
+	time.Sleep(50 * time.Millisecond)
+	pprof.StopCPUProfile()
+
+	start := time.Now()
+	time.Sleep(70 * time.Millisecond)
+	if ids := p.ProfileIds(start, time.Now()); len(ids) == 0 {
+		t.Fatal("expected the profiles to be collected again")
+	}
+}
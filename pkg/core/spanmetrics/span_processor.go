@@ -0,0 +1,185 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanmetrics
+
+import (
+	"context"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api-semconv/instrumenter/utils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	// set to "true" to derive the span metrics from every ended span
+	EnabledEnv = "OTEL_INSTRUMENTATION_SPAN_METRICS_ENABLED"
+	// maximum number of the distinct span name, kind and status, the spans
+	// beyond it are counted in a single overflow series
+	MaxSeriesEnv = "OTEL_INSTRUMENTATION_SPAN_METRICS_MAX_SERIES"
+)
+
+const defaultMaxSeries = 1000
+
+const (
+	span_calls    = "otel.span.calls"
+	span_errors   = "otel.span.errors"
+	span_duration = "otel.span.duration"
+)
+
+const (
+	spanNameKey   = attribute.Key("span.name")
+	spanKindKey   = attribute.Key("span.kind")
+	statusCodeKey = attribute.Key("status.code")
+	overflowKey   = attribute.Key("otel.metric.overflow")
+)
+
+type seriesKey struct {
+	name   string
+	kind   string
+	status string
+}
+
+type instruments struct {
+	calls    metric.Int64Counter
+	errors   metric.Int64Counter
+	duration metric.Float64Histogram
+}
+
+// SpanProcessor records the request count, the error count and the duration
+// of the ended spans, the unsampled spans never reach it so the counts are
+// sampled as well.
+type SpanProcessor struct {
+	maxSeries   int
+	instruments *instruments
+	overflow    attribute.Set
+
+	mu     sync.RWMutex
+	series map[seriesKey]attribute.Set
+}
+
+var _ sdktrace.SpanProcessor = (*SpanProcessor)(nil)
+
+// NewSpanProcessor creates the span metrics on the meter m.
+func NewSpanProcessor(m metric.Meter, maxSeries int) (*SpanProcessor, error) {
+	inst, err := newInstruments(m)
+	if err != nil {
+		return nil, err
+	}
+	if maxSeries <= 0 {
+		maxSeries = defaultMaxSeries
+	}
+	return &SpanProcessor{
+		maxSeries:   maxSeries,
+		instruments: inst,
+		overflow:    attribute.NewSet(overflowKey.Bool(true)),
+		series:      make(map[seriesKey]attribute.Set),
+	}, nil
+}
+
+// NewSpanProcessorFromEnv returns nil when the span metrics are disabled.
+func NewSpanProcessorFromEnv(m metric.Meter) (*SpanProcessor, error) {
+	enabled, err := strconv.ParseBool(os.Getenv(EnabledEnv))
+	if err != nil || !enabled {
+		return nil, nil
+	}
+	maxSeries := 0
+	if val := os.Getenv(MaxSeriesEnv); val != "" {
+		if maxSeries, err = strconv.Atoi(val); err != nil {
+			log.Printf("invalid %s %q: %v", MaxSeriesEnv, val, err)
+		}
+	}
+	return NewSpanProcessor(m, maxSeries)
+}
+
+func (p *SpanProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {}
+
+func (p *SpanProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	ctx := context.Background()
+	opt := metric.WithAttributeSet(p.attributes(s))
+	p.instruments.calls.Add(ctx, 1, opt)
+	if s.Status().Code == codes.Error {
+		p.instruments.errors.Add(ctx, 1, opt)
+	}
+	p.instruments.duration.Record(ctx, utils.DurationValue(s.EndTime().Sub(s.StartTime())), opt)
+}
+
+// attributes looks the series of the span up, the lock is only taken for
+// writing the first time a series is seen.
+func (p *SpanProcessor) attributes(s sdktrace.ReadOnlySpan) attribute.Set {
+	key := seriesKey{name: s.Name(), kind: s.SpanKind().String(), status: statusCode(s.Status().Code)}
+	p.mu.RLock()
+	attrs, ok := p.series[key]
+	p.mu.RUnlock()
+	if ok {
+		return attrs
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if attrs, ok = p.series[key]; ok {
+		return attrs
+	}
+	if len(p.series) >= p.maxSeries {
+		return p.overflow
+	}
+	attrs = attribute.NewSet(spanNameKey.String(key.name), spanKindKey.String(key.kind), statusCodeKey.String(key.status))
+	p.series[key] = attrs
+	return attrs
+}
+
+func newInstruments(m metric.Meter) (*instruments, error) {
+	calls, err := m.Int64Counter(span_calls,
+		metric.WithUnit("{call}"),
+		metric.WithDescription("Number of the ended spans."))
+	if err != nil {
+		return nil, err
+	}
+	errors, err := m.Int64Counter(span_errors,
+		metric.WithUnit("{call}"),
+		metric.WithDescription("Number of the ended spans with an error status."))
+	if err != nil {
+		return nil, err
+	}
+	duration, err := m.Float64Histogram(span_duration,
+		utils.DurationHistogramOptions("Duration of the ended spans.")...)
+	if err != nil {
+		return nil, err
+	}
+	return &instruments{calls: calls, errors: errors, duration: duration}, nil
+}
+
+func statusCode(code codes.Code) string {
+	switch code {
+	case codes.Ok:
+		return "STATUS_CODE_OK"
+	case codes.Error:
+		return "STATUS_CODE_ERROR"
+	default:
+		return "STATUS_CODE_UNSET"
+	}
+}
+
+func (p *SpanProcessor) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+func (p *SpanProcessor) ForceFlush(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanmetrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric/noop"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func collect(t *testing.T, reader sdkmetric.Reader) map[string]metricdata.Aggregation {
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	data := make(map[string]metricdata.Aggregation)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			data[m.Name] = m.Data
+		}
+	}
+	return data
+}
+
+func TestSpanMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	p, err := NewSpanProcessor(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test"), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(p)).Tracer("test")
+
+	var span trace.Span
+	for i := 0; i < 2; i++ {
+		_, span = tracer.Start(context.Background(), "GET /users", trace.WithSpanKind(trace.SpanKindServer))
+		span.End()
+	}
+	_, span = tracer.Start(context.Background(), "find", trace.WithSpanKind(trace.SpanKindClient))
+	span.RecordError(errors.New("timeout"))
+	span.SetStatus(codes.Error, "timeout")
+	span.End()
+	// the series limit is reached
+	_, span = tracer.Start(context.Background(), "insert")
+	span.End()
+
+	data := collect(t, reader)
+	calls := data[span_calls].(metricdata.Sum[int64]).DataPoints
+	if len(calls) != 3 {
+		t.Fatalf("expected 3 series, got %v", calls)
+	}
+	counts := make(map[string]int64)
+	for _, dp := range calls {
+		if v, ok := dp.Attributes.Value(overflowKey); ok && v.AsBool() {
+			counts["overflow"] = dp.Value
+			continue
+		}
+		name, _ := dp.Attributes.Value(spanNameKey)
+		kind, _ := dp.Attributes.Value(spanKindKey)
+		status, _ := dp.Attributes.Value(statusCodeKey)
+		counts[name.AsString()+"|"+kind.AsString()+"|"+status.AsString()] = dp.Value
+	}
+	expected := map[string]int64{
+		"GET /users|server|STATUS_CODE_UNSET": 2,
+		"find|client|STATUS_CODE_ERROR":       1,
+		"overflow":                            1,
+	}
+	for key, count := range expected {
+		if counts[key] != count {
+			t.Fatalf("expected %d calls for %s, got %v", count, key, counts)
+		}
+	}
+
+	errs := data[span_errors].(metricdata.Sum[int64]).DataPoints
+	if len(errs) != 1 || errs[0].Value != 1 {
+		t.Fatalf("expected a single error, got %v", errs)
+	}
+	if name, _ := errs[0].Attributes.Value(spanNameKey); name.AsString() != "find" {
+		t.Fatalf("unexpected error series %v", errs[0].Attributes)
+	}
+	durations := data[span_duration].(metricdata.Histogram[float64]).DataPoints
+	if len(durations) != 3 {
+		t.Fatalf("expected 3 duration series, got %v", durations)
+	}
+}
+
+func TestSpanMetricsFromEnv(t *testing.T) {
+	m := noop.NewMeterProvider().Meter("test")
+	t.Setenv(EnabledEnv, "")
+	if p, err := NewSpanProcessorFromEnv(m); p != nil || err != nil {
+		t.Fatal("expected the span metrics to be disabled by default")
+	}
+	t.Setenv(EnabledEnv, "true")
+	t.Setenv(MaxSeriesEnv, "10")
+	if p, _ := NewSpanProcessorFromEnv(m); p == nil || p.maxSeries != 10 {
+		t.Fatalf("unexpected processor %v", p)
+	}
+	t.Setenv(MaxSeriesEnv, "many")
+	if p, _ := NewSpanProcessorFromEnv(m); p.maxSeries != defaultMaxSeries {
+		t.Fatalf("expected the default limit, got %d", p.maxSeries)
+	}
+}
@@ -95,6 +95,7 @@ func (h *AILLMAttrsExtractor[REQUEST, RESPONSE, GETTER1, GETTER2]) OnStart(attri
 	if h.Base.AttributesFilter != nil {
 		attributes = h.Base.AttributesFilter(attributes)
 	}
+	recordMessages[REQUEST, RESPONSE](parentContext, h.LLMGetter, h.Base.CommonGetter.GetAISystem(request), request)
 	return attributes, parentContext
 }
 func (h *AILLMAttrsExtractor[REQUEST, RESPONSE, GETTER1, GETTER2]) OnEnd(attributes []attribute.KeyValue, context context.Context, request REQUEST, response RESPONSE, err error) ([]attribute.KeyValue, context.Context) {
@@ -115,6 +116,11 @@ func (h *AILLMAttrsExtractor[REQUEST, RESPONSE, GETTER1, GETTER2]) OnEnd(attribu
 	}, attribute.KeyValue{
 		Key:   semconv.GenAIResponseIDKey,
 		Value: attribute.StringValue(h.LLMGetter.GetAIResponseID(request, response)),
+	}, attribute.KeyValue{
+		// the input tokens are usually reported along with the response
+		Key:   semconv.GenAIUsageInputTokensKey,
+		Value: attribute.Int64Value(h.LLMGetter.GetAIUsageInputTokens(request)),
 	})
+	recordChoices(context, h.LLMGetter, h.Base.CommonGetter.GetAISystem(request), request, response)
 	return attributes, context
 }
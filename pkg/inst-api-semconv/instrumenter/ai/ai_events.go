@@ -0,0 +1,231 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strconv"
+	"sync"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// set to "true" to record the prompts and completions as span events
+	captureMessageContentEnv = "OTEL_INSTRUMENTATION_GENAI_CAPTURE_MESSAGE_CONTENT"
+	// the max bytes recorded per content, the rest is dropped
+	captureMessageContentMaxLengthEnv = "OTEL_INSTRUMENTATION_GENAI_CAPTURE_MESSAGE_CONTENT_MAX_LENGTH"
+)
+
+const defaultMessageContentMaxLength = 4096
+
+// the event names defined by the gen_ai semantic conventions
+const (
+	systemMessageEventName    = "gen_ai.system.message"
+	userMessageEventName      = "gen_ai.user.message"
+	assistantMessageEventName = "gen_ai.assistant.message"
+	toolMessageEventName      = "gen_ai.tool.message"
+	choiceEventName           = "gen_ai.choice"
+)
+
+// the roles of the messages, they map to the event names
+const (
+	RoleSystem    = "system"
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+	RoleTool      = "tool"
+)
+
+// GenAIToolCall is a tool call requested by the model.
+type GenAIToolCall struct {
+	ID        string `json:"id,omitempty"`
+	Type      string `json:"type,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Arguments string `json:"arguments,omitempty"`
+}
+
+// GenAIMessage is a message sent to the model, ToolCallID is only set for
+// the tool messages.
+type GenAIMessage struct {
+	Role       string
+	Content    string
+	ToolCalls  []GenAIToolCall
+	ToolCallID string
+}
+
+// GenAIChoice is a completion returned by the model.
+type GenAIChoice struct {
+	Index        int
+	FinishReason string
+	Content      string
+	ToolCalls    []GenAIToolCall
+}
+
+// MessageGetter is implemented by the llm attrs getters supporting content
+// capture, the prompts are recorded on start and the choices on end.
+type MessageGetter[REQUEST any, RESPONSE any] interface {
+	GetAIRequestMessages(request REQUEST) []GenAIMessage
+	GetAIResponseChoices(request REQUEST, response RESPONSE) []GenAIChoice
+}
+
+// MessageContentRedactor rewrites the captured content before it is
+// recorded, e.g. to mask the personal information.
+type MessageContentRedactor func(content string) string
+
+type messageCapture struct {
+	enabled   bool
+	maxLength int
+	redactor  MessageContentRedactor
+	mu        sync.RWMutex
+}
+
+var messageCaptureConfig = newMessageCaptureFromEnv()
+
+func newMessageCaptureFromEnv() *messageCapture {
+	c := &messageCapture{
+		enabled:   os.Getenv(captureMessageContentEnv) == "true",
+		maxLength: defaultMessageContentMaxLength,
+	}
+	if length, err := strconv.Atoi(os.Getenv(captureMessageContentMaxLengthEnv)); err == nil && length > 0 {
+		c.maxLength = length
+	}
+	return c
+}
+
+// MessageContentCaptureEnabled reports whether the prompts and completions
+// are recorded, the getters can skip collecting them otherwise.
+func MessageContentCaptureEnabled() bool {
+	return messageCaptureConfig.enabled
+}
+
+// SetMessageContentRedactor registers the redactor applied to every captured
+// content and tool call argument, nil removes it.
+func SetMessageContentRedactor(redactor MessageContentRedactor) {
+	messageCaptureConfig.mu.Lock()
+	defer messageCaptureConfig.mu.Unlock()
+	messageCaptureConfig.redactor = redactor
+}
+
+// sanitize redacts the content first so that the redactor sees the whole
+// value, then truncates it on a rune boundary.
+func (c *messageCapture) sanitize(content string) (string, bool) {
+	c.mu.RLock()
+	redactor := c.redactor
+	c.mu.RUnlock()
+	if redactor != nil {
+		content = redactor(content)
+	}
+	if len(content) <= c.maxLength {
+		return content, false
+	}
+	end := c.maxLength
+	for end > 0 && !utf8.RuneStart(content[end]) {
+		end--
+	}
+	return content[:end], true
+}
+
+func (c *messageCapture) appendContent(attrs []attribute.KeyValue, key string, content string) []attribute.KeyValue {
+	if content == "" {
+		return attrs
+	}
+	content, truncated := c.sanitize(content)
+	attrs = append(attrs, attribute.String(key, content))
+	if truncated {
+		attrs = append(attrs, attribute.Bool(key+".truncated", true))
+	}
+	return attrs
+}
+
+func (c *messageCapture) appendToolCalls(attrs []attribute.KeyValue, key string, toolCalls []GenAIToolCall) []attribute.KeyValue {
+	if len(toolCalls) == 0 {
+		return attrs
+	}
+	sanitized := make([]GenAIToolCall, len(toolCalls))
+	for i, toolCall := range toolCalls {
+		sanitized[i] = toolCall
+		sanitized[i].Arguments, _ = c.sanitize(toolCall.Arguments)
+	}
+	data, err := json.Marshal(sanitized)
+	if err != nil {
+		return attrs
+	}
+	return append(attrs, attribute.String(key, string(data)))
+}
+
+func messageEventName(role string) string {
+	switch role {
+	case RoleSystem:
+		return systemMessageEventName
+	case RoleAssistant:
+		return assistantMessageEventName
+	case RoleTool:
+		return toolMessageEventName
+	default:
+		return userMessageEventName
+	}
+}
+
+func recordMessages[REQUEST any, RESPONSE any](ctx context.Context, getter any, system string, request REQUEST) {
+	if !messageCaptureConfig.enabled {
+		return
+	}
+	messageGetter, ok := getter.(MessageGetter[REQUEST, RESPONSE])
+	if !ok {
+		return
+	}
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	for _, message := range messageGetter.GetAIRequestMessages(request) {
+		attrs := []attribute.KeyValue{semconv.GenAISystemKey.String(system), attribute.String("role", message.Role)}
+		attrs = messageCaptureConfig.appendContent(attrs, "content", message.Content)
+		attrs = messageCaptureConfig.appendToolCalls(attrs, "tool_calls", message.ToolCalls)
+		if message.ToolCallID != "" {
+			attrs = append(attrs, attribute.String("id", message.ToolCallID))
+		}
+		span.AddEvent(messageEventName(message.Role), trace.WithAttributes(attrs...))
+	}
+}
+
+func recordChoices[REQUEST any, RESPONSE any](ctx context.Context, getter any, system string, request REQUEST, response RESPONSE) {
+	if !messageCaptureConfig.enabled {
+		return
+	}
+	messageGetter, ok := getter.(MessageGetter[REQUEST, RESPONSE])
+	if !ok {
+		return
+	}
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	for _, choice := range messageGetter.GetAIResponseChoices(request, response) {
+		attrs := []attribute.KeyValue{
+			semconv.GenAISystemKey.String(system),
+			attribute.Int("index", choice.Index),
+			attribute.String("finish_reason", choice.FinishReason),
+		}
+		attrs = messageCaptureConfig.appendContent(attrs, "message.content", choice.Content)
+		attrs = messageCaptureConfig.appendToolCalls(attrs, "message.tool_calls", choice.ToolCalls)
+		span.AddEvent(choiceEventName, trace.WithAttributes(attrs...))
+	}
+}
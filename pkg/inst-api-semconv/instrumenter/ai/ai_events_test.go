@@ -0,0 +1,121 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ai
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type messageRequest struct {
+	ollamaRequest
+}
+
+func (messageRequest) GetAIRequestMessages(request testRequest) []GenAIMessage {
+	return []GenAIMessage{
+		{Role: RoleSystem, Content: "you are a helpful assistant"},
+		{Role: RoleUser, Content: "my phone is 123456, what is the weather?"},
+		{Role: RoleAssistant, ToolCalls: []GenAIToolCall{{ID: "call-1", Type: "function", Name: "weather", Arguments: `{"phone":"123456"}`}}},
+		{Role: RoleTool, Content: "sunny", ToolCallID: "call-1"},
+	}
+}
+
+func (messageRequest) GetAIResponseChoices(request testRequest, response testResponse) []GenAIChoice {
+	return []GenAIChoice{{Index: 0, FinishReason: "stop", Content: strings.Repeat("é", 8)}}
+}
+
+func enableMessageCapture(t *testing.T, maxLength string) {
+	t.Setenv(captureMessageContentEnv, "true")
+	t.Setenv(captureMessageContentMaxLengthEnv, maxLength)
+	old := messageCaptureConfig
+	messageCaptureConfig = newMessageCaptureFromEnv()
+	t.Cleanup(func() { messageCaptureConfig = old })
+}
+
+func eventAttribute(event sdktrace.Event, key attribute.Key) string {
+	for _, attr := range event.Attributes {
+		if attr.Key == key {
+			return attr.Value.Emit()
+		}
+	}
+	return ""
+}
+
+func recordLLMEvents(t *testing.T) []sdktrace.Event {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	ctx, span := tp.Tracer("test").Start(context.Background(), "chat")
+	extractor := AILLMAttrsExtractor[testRequest, testResponse, commonRequest, messageRequest]{}
+	extractor.OnStart(nil, ctx, testRequest{System: "openai"})
+	extractor.OnEnd(nil, ctx, testRequest{System: "openai"}, testResponse{}, nil)
+	span.End()
+	return sr.Ended()[0].Events()
+}
+
+func TestMessageCaptureDisabled(t *testing.T) {
+	t.Setenv(captureMessageContentEnv, "")
+	old := messageCaptureConfig
+	defer func() { messageCaptureConfig = old }()
+	messageCaptureConfig = newMessageCaptureFromEnv()
+	if MessageContentCaptureEnabled() {
+		t.Fatal("expected the content capture to be disabled by default")
+	}
+	if events := recordLLMEvents(t); len(events) != 0 {
+		t.Fatalf("expected no events, got %v", events)
+	}
+}
+
+func TestMessageEvents(t *testing.T) {
+	enableMessageCapture(t, "10")
+	SetMessageContentRedactor(func(content string) string {
+		return strings.ReplaceAll(content, "123456", "******")
+	})
+	defer SetMessageContentRedactor(nil)
+
+	events := recordLLMEvents(t)
+	expected := []string{systemMessageEventName, userMessageEventName, assistantMessageEventName, toolMessageEventName, choiceEventName}
+	if len(events) != len(expected) {
+		t.Fatalf("expected %d events, got %v", len(expected), events)
+	}
+	for i, name := range expected {
+		if events[i].Name != name {
+			t.Fatalf("expected event %s, got %s", name, events[i].Name)
+		}
+		if eventAttribute(events[i], "gen_ai.system") != "openai" {
+			t.Fatalf("expected gen_ai.system on %s", name)
+		}
+	}
+	if content := eventAttribute(events[1], "content"); content != "my phone i" || eventAttribute(events[1], "content.truncated") != "true" {
+		t.Fatalf("expected the user message to be truncated, got %s", content)
+	}
+	if toolCalls := eventAttribute(events[2], "tool_calls"); strings.Contains(toolCalls, "123456") || !strings.Contains(toolCalls, "weather") {
+		t.Fatalf("expected the tool call arguments to be redacted, got %s", toolCalls)
+	}
+	if id := eventAttribute(events[3], "id"); id != "call-1" {
+		t.Fatalf("expected the tool call id, got %s", id)
+	}
+	// the multi-byte runes are never split
+	if content := eventAttribute(events[4], "message.content"); content != strings.Repeat("é", 5) {
+		t.Fatalf("unexpected choice content %s", content)
+	}
+	if reason := eventAttribute(events[4], "finish_reason"); reason != "stop" {
+		t.Fatalf("unexpected finish reason %s", reason)
+	}
+}
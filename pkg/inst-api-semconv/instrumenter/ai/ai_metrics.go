@@ -0,0 +1,206 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api-semconv/instrumenter/utils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+)
+
+const gen_ai_client_operation_duration = "gen_ai.client.operation.duration"
+
+const gen_ai_client_token_usage = "gen_ai.client.token.usage"
+
+// there is no client side time to first token in the semantic conventions
+// yet, the name follows gen_ai.server.time_to_first_token
+const gen_ai_client_time_to_first_token = "gen_ai.client.time_to_first_token"
+
+// the bucket boundaries advised by the semantic conventions
+var tokenUsageBuckets = []float64{1, 4, 16, 64, 256, 1024, 4096, 16384, 65536, 262144, 1048576, 4194304, 16777216, 67108864}
+
+type AIClientMetric struct {
+	key                     attribute.Key
+	clientOperationDuration metric.Float64Histogram
+	clientTokenUsage        metric.Int64Histogram
+	clientTimeToFirstToken  metric.Float64Histogram
+}
+
+var mu sync.Mutex
+
+var aiMetricsConv = map[attribute.Key]bool{
+	semconv.GenAIOperationNameKey: true,
+	semconv.GenAISystemKey:        true,
+	semconv.GenAIRequestModelKey:  true,
+	semconv.GenAIResponseModelKey: true,
+	semconv.ServerAddressKey:      true,
+	semconv.ServerPortKey:         true,
+	semconv.ErrorTypeKey:          true,
+}
+
+var globalMeter metric.Meter
+
+// InitAIMetrics so we need to make sure the otel_setup is executed before all the init() function
+// related to issue https://github.com/alibaba/opentelemetry-go-auto-instrumentation/issues/48
+func InitAIMetrics(m metric.Meter) {
+	mu.Lock()
+	defer mu.Unlock()
+	globalMeter = m
+}
+
+func AIClientMetrics(key string) *AIClientMetric {
+	mu.Lock()
+	defer mu.Unlock()
+	return &AIClientMetric{key: attribute.Key(key)}
+}
+
+// for test only
+func newAIClientMetric(key string, meter metric.Meter) (*AIClientMetric, error) {
+	m := &AIClientMetric{
+		key: attribute.Key(key),
+	}
+	if err := m.initMeasures(meter); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (h *AIClientMetric) initMeasures(meter metric.Meter) error {
+	mu.Lock()
+	defer mu.Unlock()
+	if meter == nil {
+		return errors.New("nil meter")
+	}
+	d, err := meter.Float64Histogram(gen_ai_client_operation_duration,
+		utils.DurationHistogramOptions("GenAI operation duration.")...)
+	if err != nil {
+		return errors.New(fmt.Sprintf("failed to create gen_ai.client.operation.duration histogram, %v", err))
+	}
+	u, err := meter.Int64Histogram(gen_ai_client_token_usage,
+		metric.WithUnit("{token}"),
+		metric.WithDescription("Measures number of input and output tokens used."),
+		metric.WithExplicitBucketBoundaries(tokenUsageBuckets...))
+	if err != nil {
+		return errors.New(fmt.Sprintf("failed to create gen_ai.client.token.usage histogram, %v", err))
+	}
+	f, err := meter.Float64Histogram(gen_ai_client_time_to_first_token,
+		utils.DurationHistogramOptions("Time to receive the first chunk of a streaming response.")...)
+	if err != nil {
+		return errors.New(fmt.Sprintf("failed to create gen_ai.client.time_to_first_token histogram, %v", err))
+	}
+	h.clientTokenUsage, h.clientTimeToFirstToken = u, f
+	h.clientOperationDuration = d
+	return nil
+}
+
+type aiMetricContext struct {
+	startTime       time.Time
+	startAttributes []attribute.KeyValue
+	firstToken      *firstTokenTime
+}
+
+type firstTokenTime struct {
+	nanos atomic.Int64
+}
+
+type firstTokenKey struct{}
+
+// RecordFirstToken marks the arrival of the first chunk of a streaming
+// response, ctx is the context returned by the instrumenter start and only
+// the first call counts.
+func RecordFirstToken(ctx context.Context) {
+	if t, ok := ctx.Value(firstTokenKey{}).(*firstTokenTime); ok {
+		t.nanos.CompareAndSwap(0, time.Now().UnixNano())
+	}
+}
+
+// tokenCount prefers the end attributes, the input tokens may only be known
+// once the operation ends.
+func tokenCount(endAttributes, startAttributes []attribute.KeyValue, key attribute.Key) int64 {
+	for _, attrs := range [][]attribute.KeyValue{endAttributes, startAttributes} {
+		for _, attr := range attrs {
+			if attr.Key == key && attr.Value.AsInt64() > 0 {
+				return attr.Value.AsInt64()
+			}
+		}
+	}
+	return 0
+}
+
+func (h *AIClientMetric) OnBeforeStart(parentContext context.Context, startTime time.Time) context.Context {
+	return parentContext
+}
+
+func (h *AIClientMetric) OnBeforeEnd(ctx context.Context, startAttributes []attribute.KeyValue, startTime time.Time) context.Context {
+	firstToken := &firstTokenTime{}
+	ctx = context.WithValue(ctx, firstTokenKey{}, firstToken)
+	return context.WithValue(ctx, h.key, aiMetricContext{
+		startTime:       startTime,
+		startAttributes: startAttributes,
+		firstToken:      firstToken,
+	})
+}
+
+func (h *AIClientMetric) OnAfterStart(context context.Context, endTime time.Time) {
+	return
+}
+
+func (h *AIClientMetric) OnAfterEnd(context context.Context, endAttributes []attribute.KeyValue, endTime time.Time) {
+	mc, ok := context.Value(h.key).(aiMetricContext)
+	if !ok {
+		return
+	}
+	startTime, startAttributes := mc.startTime, mc.startAttributes
+	if h.clientOperationDuration == nil {
+		// second chance to init the metric
+		if err := h.initMeasures(globalMeter); err != nil {
+			log.Printf("failed to create gen_ai client metrics, err is %v\n", err)
+		}
+	}
+	if h.clientOperationDuration == nil {
+		return
+	}
+	inputTokens := tokenCount(endAttributes, startAttributes, semconv.GenAIUsageInputTokensKey)
+	outputTokens := tokenCount(endAttributes, startAttributes, semconv.GenAIUsageOutputTokensKey)
+	endAttributes = append(endAttributes, startAttributes...)
+	n, metricsAttrs := utils.Shadow(endAttributes, aiMetricsConv)
+	metricsAttrs = metricsAttrs[0:n]
+	set := metric.WithAttributeSet(attribute.NewSet(metricsAttrs...))
+	h.clientOperationDuration.Record(context, utils.DurationValue(endTime.Sub(startTime)), set)
+	if nanos := mc.firstToken.nanos.Load(); nanos != 0 {
+		h.clientTimeToFirstToken.Record(context, utils.DurationValue(time.Unix(0, nanos).Sub(startTime)), set)
+	}
+	if inputTokens > 0 {
+		h.clientTokenUsage.Record(context, inputTokens, withTokenType(metricsAttrs, semconv.GenAITokenTypeInput))
+	}
+	if outputTokens > 0 {
+		h.clientTokenUsage.Record(context, outputTokens, withTokenType(metricsAttrs, semconv.GenAITokenTypeCompletion))
+	}
+}
+
+func withTokenType(attrs []attribute.KeyValue, tokenType attribute.KeyValue) metric.MeasurementOption {
+	typed := make([]attribute.KeyValue, 0, len(attrs)+1)
+	typed = append(typed, attrs...)
+	return metric.WithAttributeSet(attribute.NewSet(append(typed, tokenType)...))
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ai
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+)
+
+func collectAIMetrics(t *testing.T, reader metric.Reader) map[string]metricdata.Metrics {
+	rm := &metricdata.ResourceMetrics{}
+	if err := reader.Collect(context.Background(), rm); err != nil {
+		t.Fatal(err)
+	}
+	metrics := make(map[string]metricdata.Metrics)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			metrics[m.Name] = m
+		}
+	}
+	return metrics
+}
+
+func TestAIClientMetrics(t *testing.T) {
+	reader := metric.NewManualReader()
+	mp := metric.NewMeterProvider(metric.WithReader(reader))
+	client, err := newAIClientMetric("test", mp.Meter("test-meter"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	start := time.Now()
+	ctx = client.OnBeforeStart(ctx, start)
+	ctx = client.OnBeforeEnd(ctx, []attribute.KeyValue{
+		semconv.GenAIOperationNameChat,
+		semconv.GenAIRequestModel("qwen"),
+		semconv.GenAIUsageInputTokens(0),
+		semconv.GenAIRequestTemperature(0.5),
+	}, start)
+	RecordFirstToken(ctx)
+	client.OnAfterStart(ctx, start)
+	client.OnAfterEnd(ctx, []attribute.KeyValue{semconv.GenAIUsageInputTokens(12), semconv.GenAIUsageOutputTokens(30)}, time.Now())
+
+	metrics := collectAIMetrics(t, reader)
+	for _, name := range []string{"gen_ai.client.operation.duration", "gen_ai.client.time_to_first_token"} {
+		m, ok := metrics[name]
+		if !ok {
+			t.Fatalf("expected %s, got %v", name, metrics)
+		}
+		attrs := m.Data.(metricdata.Histogram[float64]).DataPoints[0].Attributes
+		if v, _ := attrs.Value(semconv.GenAIRequestModelKey); v.AsString() != "qwen" {
+			t.Fatalf("expected the model attribute, got %v", attrs)
+		}
+		if _, ok := attrs.Value(semconv.GenAIRequestTemperatureKey); ok {
+			t.Fatal("the temperature should not be a metric attribute")
+		}
+	}
+	usage := map[string]int64{}
+	for _, dp := range metrics["gen_ai.client.token.usage"].Data.(metricdata.Histogram[int64]).DataPoints {
+		tokenType, _ := dp.Attributes.Value(semconv.GenAITokenTypeKey)
+		usage[tokenType.AsString()] = dp.Sum
+	}
+	if usage["input"] != 12 || usage["output"] != 30 {
+		t.Fatalf("unexpected token usage %v", usage)
+	}
+}
+
+func TestAIClientMetricsWithoutStreaming(t *testing.T) {
+	reader := metric.NewManualReader()
+	mp := metric.NewMeterProvider(metric.WithReader(reader))
+	InitAIMetrics(mp.Meter("test-meter"))
+	defer InitAIMetrics(nil)
+	client := AIClientMetrics("test")
+	ctx := context.Background()
+	start := time.Now()
+	ctx = client.OnBeforeEnd(client.OnBeforeStart(ctx, start), nil, start)
+	client.OnAfterEnd(ctx, nil, time.Now())
+	metrics := collectAIMetrics(t, reader)
+	if _, ok := metrics["gen_ai.client.operation.duration"]; !ok {
+		t.Fatal("expected the metrics to be created from the global meter")
+	}
+	if _, ok := metrics["gen_ai.client.time_to_first_token"]; ok {
+		t.Fatal("expected no time to first token without streaming")
+	}
+	if _, ok := metrics["gen_ai.client.token.usage"]; ok {
+		t.Fatal("expected no token usage without tokens")
+	}
+	if _, err := newAIClientMetric("test", nil); err == nil {
+		t.Fatal("expected an error for a nil meter")
+	}
+}
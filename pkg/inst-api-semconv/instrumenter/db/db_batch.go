@@ -0,0 +1,66 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const batchOperationEventName = "db.batch.operation"
+
+// the span keeps 128 events by default, the rest are not worth building
+const maxBatchOperationEvents = 128
+
+// DbBatchOperation is one of the operations sent in a batch, e.g. a command
+// of a redis pipeline.
+type DbBatchOperation struct {
+	Operation string
+	Statement string
+	Err       error
+}
+
+// DbBatchOperationGetter is implemented by the getters of the batched
+// requests, every operation is recorded as an event of the batch span.
+type DbBatchOperationGetter[REQUEST any] interface {
+	GetBatchOperations(REQUEST) []DbBatchOperation
+}
+
+func recordBatchOperations(ctx context.Context, system string, operations []DbBatchOperation) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	for i, operation := range operations {
+		if i >= maxBatchOperationEvents {
+			break
+		}
+		statement := operation.Statement
+		if statementSanitizerEnabled {
+			statement = SanitizeStatement(system, statement)
+		}
+		attrs := []attribute.KeyValue{
+			semconv.DBOperationName(operation.Operation),
+			semconv.DBQueryText(statement),
+		}
+		if operation.Err != nil {
+			attrs = append(attrs, semconv.ExceptionMessage(operation.Err.Error()))
+		}
+		span.AddEvent(batchOperationEventName, trace.WithAttributes(attrs...))
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+)
+
+type pipelineAttrsGetter struct {
+	mongoAttrsGetter
+}
+
+func (p pipelineAttrsGetter) GetBatchOperations(request testRequest) []DbBatchOperation {
+	return []DbBatchOperation{
+		{Operation: "set", Statement: "set key value"},
+		{Operation: "get", Statement: "get missing", Err: errors.New("not found")},
+	}
+}
+
+func recordPipelineEvents(t *testing.T) []sdktrace.Event {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	ctx, span := tp.Tracer("test").Start(context.Background(), "pipeline")
+	extractor := DbClientAttrsExtractor[testRequest, any, pipelineAttrsGetter]{}
+	extractor.OnEnd(nil, ctx, testRequest{Name: "redis", Operation: "pipeline"}, nil, nil)
+	span.End()
+	return sr.Ended()[0].Events()
+}
+
+func TestDbBatchOperationEvents(t *testing.T) {
+	events := recordPipelineEvents(t)
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %v", events)
+	}
+	for _, event := range events {
+		if event.Name != batchOperationEventName {
+			t.Fatalf("unexpected event %s", event.Name)
+		}
+	}
+	attrs := events[0].Attributes
+	if attrs[0] != semconv.DBOperationName("set") || attrs[1] != semconv.DBQueryText("set key ?") {
+		t.Fatalf("unexpected attributes %v", attrs)
+	}
+	attrs = events[1].Attributes
+	if len(attrs) != 3 || attrs[2] != semconv.ExceptionMessage("not found") {
+		t.Fatalf("expected the error to be recorded, got %v", attrs)
+	}
+}
+
+func TestDbBatchOperationEventsWithoutSpan(t *testing.T) {
+	extractor := DbClientAttrsExtractor[testRequest, any, pipelineAttrsGetter]{}
+	// no panic without a recording span
+	extractor.OnEnd(nil, context.Background(), testRequest{Name: "redis"}, nil, nil)
+}
@@ -89,7 +89,7 @@ func (d *DbClientAttrsExtractor[REQUEST, RESPONSE, GETTER]) OnEnd(attrs []attrib
 	attrs, context = d.Base.OnEnd(attrs, context, request, response, err)
 	attrs = append(attrs, attribute.KeyValue{
 		Key:   semconv.DBQueryTextKey,
-		Value: attribute.StringValue(d.Base.Getter.GetStatement(request)),
+		Value: attribute.StringValue(d.statement(request)),
 	}, attribute.KeyValue{
 		Key:   semconv.DBOperationNameKey,
 		Value: attribute.StringValue(d.Base.Getter.GetOperation(request)),
@@ -100,6 +100,11 @@ func (d *DbClientAttrsExtractor[REQUEST, RESPONSE, GETTER]) OnEnd(attrs []attrib
 		Key:   semconv.DBCollectionNameKey,
 		Value: attribute.StringValue(d.Base.Getter.GetCollection(request)),
 	})
+	if portGetter, ok := any(d.Base.Getter).(DbServerPortGetter[REQUEST]); ok {
+		if port := portGetter.GetServerPort(request); port > 0 {
+			attrs = append(attrs, attribute.KeyValue{Key: semconv.ServerPortKey, Value: attribute.IntValue(port)})
+		}
+	}
 	batchSize := d.Base.Getter.GetBatchSize(request)
 	if batchSize > 0 {
 		attrs = append(attrs, attribute.KeyValue{Key: semconv.DBOperationBatchSizeKey, Value: attribute.IntValue(batchSize)})
@@ -108,10 +113,15 @@ func (d *DbClientAttrsExtractor[REQUEST, RESPONSE, GETTER]) OnEnd(attrs []attrib
 	if dbNameSpace != "" {
 		attrs = append(attrs, attribute.KeyValue{Key: semconv.DBNamespaceKey, Value: attribute.StringValue(dbNameSpace)})
 	}
+	if batchGetter, ok := any(d.Base.Getter).(DbBatchOperationGetter[REQUEST]); ok {
+		recordBatchOperations(context, d.Base.Getter.GetSystem(request), batchGetter.GetBatchOperations(request))
+	}
 	if d.Base.AttributesFilter != nil {
 		attrs = d.Base.AttributesFilter(attrs)
 	}
-	if experimentalAttributesEnabler.Enable() {
+	// the bound parameters are only recorded on demand, they usually carry
+	// the data of the customers
+	if parameterCaptureEnabled || experimentalAttributesEnabler.Enable() {
 		params := d.Base.Getter.GetParameters(request)
 		if len(params) > 0 {
 			for i, param := range params {
@@ -126,5 +136,14 @@ func (d *DbClientAttrsExtractor[REQUEST, RESPONSE, GETTER]) GetSpanKey() attribu
 	return utils.DB_CLIENT_KEY
 }
 
-// TODO: sanitize sql
+func (d *DbClientAttrsExtractor[REQUEST, RESPONSE, GETTER]) statement(request REQUEST) string {
+	if !statementSanitizerEnabled {
+		return d.Base.Getter.GetStatement(request)
+	}
+	if getter, ok := any(d.Base.Getter).(DbSanitizedStatementGetter[REQUEST]); ok {
+		return getter.GetSanitizedStatement(request)
+	}
+	return SanitizeStatement(d.Base.Getter.GetSystem(request), d.Base.Getter.GetStatement(request))
+}
+
 // TODO: batch sql
@@ -29,6 +29,12 @@ type DbClientAttrsGetter[REQUEST any] interface {
 	GetParameters(REQUEST) []any
 }
 
+// DbServerPortGetter is implemented by the getters knowing the server port,
+// server.port is only recorded when it is positive.
+type DbServerPortGetter[REQUEST any] interface {
+	GetServerPort(REQUEST) int
+}
+
 type SqlClientAttributesGetter[REQUEST any] interface {
 	DbClientCommonAttrsGetter[REQUEST]
 	GetRawStatement(REQUEST) string
@@ -0,0 +1,326 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	nurl "net/url"
+	"strconv"
+	"strings"
+)
+
+// DsnInfo is what the spans need from a data source name, the credentials
+// are never kept.
+type DsnInfo struct {
+	System   string
+	Host     string
+	Port     int
+	Database string
+}
+
+// Endpoint is the host:port recorded as the server address of the db.
+func (d DsnInfo) Endpoint() string {
+	if d.Port <= 0 {
+		return d.Host
+	}
+	return net.JoinHostPort(d.Host, strconv.Itoa(d.Port))
+}
+
+// ParseDsn parses the data source name given to sql.Open, the system is
+// resolved by the driver name even when the dsn can not be parsed.
+func ParseDsn(driverName, dsn string) (DsnInfo, error) {
+	var info DsnInfo
+	var err error
+	switch driverName {
+	case "mysql":
+		info, err = parseMySQL(dsn)
+	case "postgres", "postgresql", "pgx", "pgx/v4", "pgx/v5":
+		info, err = parsePostgres(dsn)
+	case "sqlserver", "mssql", "azuresql":
+		info, err = parseSQLServer(dsn)
+	case "clickhouse":
+		info, err = parseClickHouse(dsn)
+	case "sqlite3", "sqlite":
+		info, err = parseSQLite(dsn)
+	case "oracle", "godror", "oci8":
+		info, err = parseOracle(dsn)
+	default:
+		err = fmt.Errorf("unsupported driver: %s", driverName)
+	}
+	info.System = DbSystemOfDriver(driverName)
+	return info, err
+}
+
+// DbSystemOfDriver maps the database/sql driver name to db.system.name.
+func DbSystemOfDriver(driverName string) string {
+	switch driverName {
+	case "mysql":
+		return "mysql"
+	case "postgres", "postgresql", "pgx", "pgx/v4", "pgx/v5":
+		return "postgresql"
+	case "sqlserver", "mssql", "azuresql":
+		return "microsoft.sql_server"
+	case "clickhouse":
+		return "clickhouse"
+	case "sqlite3", "sqlite":
+		return "sqlite"
+	case "oracle", "godror", "oci8":
+		return "oracle.db"
+	}
+	return "database"
+}
+
+// splitHostPort tolerates a missing port and uses the default one instead
+func splitHostPort(hostport string, defaultPort int) (string, int) {
+	host, portStr, err := net.SplitHostPort(hostport)
+	if err != nil {
+		return strings.Trim(hostport, "[]"), defaultPort
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return host, defaultPort
+	}
+	return host, port
+}
+
+// parseURL fills the host, port and the database in the path
+func parseURL(dsn string, defaultPort int) (DsnInfo, *nurl.URL, error) {
+	u, err := nurl.Parse(firstURLHost(dsn))
+	if err != nil {
+		return DsnInfo{}, nil, err
+	}
+	var info DsnInfo
+	info.Host, info.Port = splitHostPort(u.Host, defaultPort)
+	info.Database = strings.TrimPrefix(u.Path, "/")
+	return info, u, nil
+}
+
+// firstURLHost keeps the first host of a multi-host url, net/url rejects
+// them, e.g. postgres://h1:5432,h2:5433/db
+func firstURLHost(dsn string) string {
+	scheme, rest, ok := strings.Cut(dsn, "://")
+	if !ok {
+		return dsn
+	}
+	end := strings.IndexAny(rest, "/?")
+	if end < 0 {
+		end = len(rest)
+	}
+	authority := rest[:end]
+	at := strings.LastIndexByte(authority, '@')
+	first, _, multi := strings.Cut(authority[at+1:], ",")
+	if !multi {
+		return dsn
+	}
+	return scheme + "://" + authority[:at+1] + first + rest[end:]
+}
+
+// parseKeyValues parses the "key=value" pairs separated by sep, the values
+// can be single quoted when sep is a space.
+func parseKeyValues(dsn string, sep byte) map[string]string {
+	values := make(map[string]string)
+	for len(dsn) > 0 {
+		dsn = strings.TrimLeft(dsn, " \t\n\r"+string(sep))
+		eq := strings.IndexByte(dsn, '=')
+		if eq < 0 {
+			break
+		}
+		key := strings.ToLower(strings.TrimSpace(dsn[:eq]))
+		dsn = strings.TrimLeft(dsn[eq+1:], " \t")
+		var value string
+		if len(dsn) > 0 && (dsn[0] == '\'' || dsn[0] == '"') {
+			quote := dsn[0]
+			var b strings.Builder
+			i := 1
+			for ; i < len(dsn) && dsn[i] != quote; i++ {
+				if dsn[i] == '\\' && i+1 < len(dsn) {
+					i++
+				}
+				b.WriteByte(dsn[i])
+			}
+			value, dsn = b.String(), dsn[min(i+1, len(dsn)):]
+		} else {
+			end := strings.IndexByte(dsn, sep)
+			if end < 0 {
+				end = len(dsn)
+			}
+			value, dsn = strings.TrimSpace(dsn[:end]), dsn[end:]
+		}
+		values[key] = value
+	}
+	return values
+}
+
+// parseMySQL parses [user[:password]@][net[(addr)]]/dbname[?param1=value1&paramN=valueN]
+func parseMySQL(dsn string) (DsnInfo, error) {
+	info := DsnInfo{Host: "127.0.0.1", Port: 3306}
+	slash := strings.LastIndexByte(dsn, '/')
+	if slash < 0 {
+		return info, errors.New("invalid MySQL DSN")
+	}
+	info.Database, _, _ = strings.Cut(dsn[slash+1:], "?")
+	prefix := dsn[:slash]
+	if at := strings.LastIndexByte(prefix, '@'); at >= 0 {
+		prefix = prefix[at+1:]
+	}
+	i, j := strings.IndexByte(prefix, '('), strings.LastIndexByte(prefix, ')')
+	if i < 0 || j < i {
+		return info, nil
+	}
+	addr := prefix[i+1 : j]
+	if prefix[:i] == "unix" {
+		info.Host, info.Port = addr, 0
+		return info, nil
+	}
+	if addr != "" {
+		info.Host, info.Port = splitHostPort(addr, 3306)
+	}
+	return info, nil
+}
+
+// parsePostgres parses both the url and the "key=value" dsns of lib/pq and pgx
+func parsePostgres(dsn string) (DsnInfo, error) {
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		info, u, err := parseURL(dsn, 5432)
+		if err != nil {
+			return info, err
+		}
+		if info.Host == "" {
+			info.Host = "localhost"
+		}
+		query := u.Query()
+		if host := query.Get("host"); host != "" {
+			info.Host = host
+		}
+		if port, err := strconv.Atoi(query.Get("port")); err == nil {
+			info.Port = port
+		}
+		if database := query.Get("dbname"); database != "" {
+			info.Database = database
+		}
+		return info, nil
+	}
+	if !strings.Contains(dsn, "=") {
+		return DsnInfo{}, errors.New("invalid PostgreSQL DSN")
+	}
+	values := parseKeyValues(dsn, ' ')
+	info := DsnInfo{Host: "localhost", Port: 5432, Database: values["dbname"]}
+	if host, _, _ := strings.Cut(values["host"], ","); host != "" {
+		info.Host = host
+	}
+	if port, _, _ := strings.Cut(values["port"], ","); port != "" {
+		if p, err := strconv.Atoi(port); err == nil {
+			info.Port = p
+		}
+	}
+	return info, nil
+}
+
+// parseSQLServer parses the url, the ado and the odbc dsns of go-mssqldb
+func parseSQLServer(dsn string) (DsnInfo, error) {
+	if strings.HasPrefix(dsn, "sqlserver://") {
+		info, u, err := parseURL(dsn, 1433)
+		if err != nil {
+			return info, err
+		}
+		// the path is the instance name
+		info.Database = u.Query().Get("database")
+		return info, nil
+	}
+	values := parseKeyValues(strings.TrimPrefix(dsn, "odbc:"), ';')
+	server := values["server"]
+	if server == "" {
+		server = values["data source"]
+	}
+	if server == "" {
+		return DsnInfo{}, errors.New("invalid SQL Server DSN")
+	}
+	server = strings.TrimPrefix(server, "tcp:")
+	info := DsnInfo{Port: 1433, Database: values["database"]}
+	if info.Database == "" {
+		info.Database = values["initial catalog"]
+	}
+	host, port, hasPort := strings.Cut(server, ",")
+	// host\instance
+	host, _, _ = strings.Cut(host, "\\")
+	info.Host = host
+	if !hasPort {
+		port = values["port"]
+	}
+	if p, err := strconv.Atoi(strings.TrimSpace(port)); err == nil {
+		info.Port = p
+	}
+	return info, nil
+}
+
+// parseClickHouse parses the clickhouse://, tcp:// and http(s):// dsns
+func parseClickHouse(dsn string) (DsnInfo, error) {
+	defaultPort := 9000
+	switch {
+	case strings.HasPrefix(dsn, "https://"):
+		defaultPort = 8443
+	case strings.HasPrefix(dsn, "http://"):
+		defaultPort = 8123
+	case strings.HasPrefix(dsn, "clickhouse://"), strings.HasPrefix(dsn, "tcp://"):
+	default:
+		return DsnInfo{}, errors.New("invalid ClickHouse DSN")
+	}
+	info, u, err := parseURL(dsn, defaultPort)
+	if err != nil {
+		return info, err
+	}
+	query := u.Query()
+	if database := query.Get("database"); database != "" {
+		info.Database = database
+	}
+	return info, nil
+}
+
+// parseSQLite records the file as the database, there is no server
+func parseSQLite(dsn string) (DsnInfo, error) {
+	database, _, _ := strings.Cut(strings.TrimPrefix(dsn, "file:"), "?")
+	if database == "" {
+		return DsnInfo{}, errors.New("invalid SQLite DSN")
+	}
+	return DsnInfo{Database: database}, nil
+}
+
+// parseOracle parses the go-ora url, the godror key=value and the
+// user/password@host:port/service dsns
+func parseOracle(dsn string) (DsnInfo, error) {
+	if strings.HasPrefix(dsn, "oracle://") {
+		info, _, err := parseURL(dsn, 1521)
+		return info, err
+	}
+	var info DsnInfo
+	connect := dsn
+	if strings.Contains(dsn, "connectString=") {
+		connect = parseKeyValues(dsn, ' ')["connectstring"]
+	} else if at := strings.LastIndexByte(dsn, '@'); at >= 0 {
+		connect = dsn[at+1:]
+	}
+	// [//]host[:port][/service_name]
+	connect = strings.TrimPrefix(connect, "//")
+	if connect == "" || strings.HasPrefix(connect, "(") {
+		// the tns descriptors are not supported
+		return info, errors.New("invalid Oracle DSN")
+	}
+	hostport, service, _ := strings.Cut(connect, "/")
+	info.Host, info.Port = splitHostPort(hostport, 1521)
+	info.Database, _, _ = strings.Cut(service, ":")
+	return info, nil
+}
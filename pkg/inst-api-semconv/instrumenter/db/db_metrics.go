@@ -40,6 +40,7 @@ var dbMetricsConv = map[attribute.Key]bool{
 	semconv.DBSystemNameKey:    true,
 	semconv.DBOperationNameKey: true,
 	semconv.ServerAddressKey:   true,
+	semconv.ServerPortKey:      true,
 	semconv.DBNamespaceKey:     true,
 }
 
@@ -79,8 +80,7 @@ func newDbClientRequestDurationMeasures(meter metric.Meter) (metric.Float64Histo
 		return nil, errors.New("nil meter")
 	}
 	d, err := meter.Float64Histogram(db_client_request_duration,
-		metric.WithUnit("ms"),
-		metric.WithDescription("Duration of Db client requests."))
+		utils.DurationHistogramOptions("Duration of Db client requests.")...)
 	if err == nil {
 		return d, nil
 	} else {
@@ -123,6 +123,6 @@ func (h DbClientMetric) OnAfterEnd(context context.Context, endAttributes []attr
 	endAttributes = append(endAttributes, startAttributes...)
 	n, metricsAttrs := utils.Shadow(endAttributes, dbMetricsConv)
 	if h.clientRequestDuration != nil {
-		h.clientRequestDuration.Record(context, float64(endTime.Sub(startTime).Milliseconds()), metric.WithAttributeSet(attribute.NewSet(metricsAttrs[0:n]...)))
+		h.clientRequestDuration.Record(context, utils.DurationValue(endTime.Sub(startTime)), metric.WithAttributeSet(attribute.NewSet(metricsAttrs[0:n]...)))
 	}
 }
@@ -0,0 +1,227 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api-semconv/instrumenter/utils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+)
+
+const db_client_connection_count = "db.client.connection.count"
+
+const db_client_connection_max = "db.client.connection.max"
+
+const db_client_connection_idle_max = "db.client.connection.idle.max"
+
+const db_client_connection_timeouts = "db.client.connection.timeouts"
+
+const db_client_connection_wait_time = "db.client.connection.wait_time"
+
+const db_client_connection_use_time = "db.client.connection.use_time"
+
+// the accumulated wait time kept by the pools, it is a counter so it does not
+// take the name of the db.client.connection.wait_time histogram
+const db_client_connection_total_wait_time = "db.client.connection.total_wait_time"
+
+// DbPoolStats is a snapshot of a connection pool, the wait and use time of
+// every acquisition are recorded by DbPoolTimes.
+type DbPoolStats struct {
+	Idle int64
+	Used int64
+	Max  int64
+	// negative when the pool does not limit or tell the idle connections
+	IdleMax int64
+	// the total number of timeouts, negative when the pool does not count them
+	Timeouts int64
+	// the total time blocked waiting for a new connection, negative when the
+	// pool does not measure it
+	WaitTime time.Duration
+}
+
+type dbPoolMetric struct {
+	count    metric.Int64ObservableUpDownCounter
+	max      metric.Int64ObservableUpDownCounter
+	idleMax  metric.Int64ObservableUpDownCounter
+	timeouts metric.Int64ObservableCounter
+	// the accumulated wait time
+	totalWaitTime metric.Float64ObservableCounter
+	waitTime      metric.Float64Histogram
+	useTime       metric.Float64Histogram
+}
+
+var poolMetric *dbPoolMetric
+
+// the pool names handed out by DbPoolName
+var poolNames = make(map[string]int)
+
+func newDbPoolMetric(meter metric.Meter) (*dbPoolMetric, error) {
+	if meter == nil {
+		return nil, errors.New("nil meter")
+	}
+	m := &dbPoolMetric{}
+	var err error
+	if m.count, err = meter.Int64ObservableUpDownCounter(db_client_connection_count,
+		metric.WithUnit("{connection}"),
+		metric.WithDescription("The number of connections that are currently in state described by the state attribute.")); err != nil {
+		return nil, errors.New(fmt.Sprintf("failed to create db.client.connection.count counter, %v", err))
+	}
+	if m.max, err = meter.Int64ObservableUpDownCounter(db_client_connection_max,
+		metric.WithUnit("{connection}"),
+		metric.WithDescription("The maximum number of open connections allowed.")); err != nil {
+		return nil, errors.New(fmt.Sprintf("failed to create db.client.connection.max counter, %v", err))
+	}
+	if m.idleMax, err = meter.Int64ObservableUpDownCounter(db_client_connection_idle_max,
+		metric.WithUnit("{connection}"),
+		metric.WithDescription("The maximum number of idle open connections allowed.")); err != nil {
+		return nil, errors.New(fmt.Sprintf("failed to create db.client.connection.idle.max counter, %v", err))
+	}
+	if m.timeouts, err = meter.Int64ObservableCounter(db_client_connection_timeouts,
+		metric.WithUnit("{timeout}"),
+		metric.WithDescription("The number of connection timeouts that have occurred trying to obtain a connection from the pool.")); err != nil {
+		return nil, errors.New(fmt.Sprintf("failed to create db.client.connection.timeouts counter, %v", err))
+	}
+	if m.totalWaitTime, err = meter.Float64ObservableCounter(db_client_connection_total_wait_time,
+		metric.WithUnit(utils.DurationUnit()),
+		metric.WithDescription("The total time it took to obtain open connections from the pool.")); err != nil {
+		return nil, errors.New(fmt.Sprintf("failed to create db.client.connection.total_wait_time counter, %v", err))
+	}
+	if m.waitTime, err = meter.Float64Histogram(db_client_connection_wait_time,
+		utils.DurationHistogramOptions("The time it took to obtain an open connection from the pool.")...); err != nil {
+		return nil, errors.New(fmt.Sprintf("failed to create db.client.connection.wait_time histogram, %v", err))
+	}
+	if m.useTime, err = meter.Float64Histogram(db_client_connection_use_time,
+		utils.DurationHistogramOptions("The time between borrowing a connection and returning it to the pool.")...); err != nil {
+		return nil, errors.New(fmt.Sprintf("failed to create db.client.connection.use_time histogram, %v", err))
+	}
+	return m, nil
+}
+
+// DbPoolName makes the name unique in the process by numbering the pools
+// sharing the same name, e.g. the pools opened with the same dsn.
+func DbPoolName(name string) string {
+	mu.Lock()
+	defer mu.Unlock()
+	poolNames[name]++
+	if n := poolNames[name]; n > 1 {
+		return name + "#" + strconv.Itoa(n)
+	}
+	return name
+}
+
+// RegisterDbPoolMetrics observes the stats of a connection pool on every
+// collection until the returned registration is unregistered. The pool name
+// should be unique in the process, see DbPoolName.
+func RegisterDbPoolMetrics(poolName string, serverAddress string, stats func() DbPoolStats) (metric.Registration, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	if err := initPoolMetric(); err != nil {
+		return nil, err
+	}
+	return registerDbPoolMetrics(globalMeter, poolMetric, poolName, serverAddress, stats)
+}
+
+// initPoolMetric creates the pool metrics once, mu must be held.
+func initPoolMetric() error {
+	if poolMetric != nil {
+		return nil
+	}
+	m, err := newDbPoolMetric(globalMeter)
+	if err != nil {
+		return err
+	}
+	poolMetric = m
+	return nil
+}
+
+func poolAttributes(poolName string, serverAddress string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{semconv.DBClientConnectionPoolName(poolName)}
+	if serverAddress != "" {
+		attrs = append(attrs, semconv.ServerAddress(serverAddress))
+	}
+	return attrs
+}
+
+func registerDbPoolMetrics(meter metric.Meter, m *dbPoolMetric, poolName string, serverAddress string, stats func() DbPoolStats) (metric.Registration, error) {
+	attrs := poolAttributes(poolName, serverAddress)
+	set := attribute.NewSet(attrs...)
+	idleSet := attribute.NewSet(append(attrs, semconv.DBClientConnectionStateIdle)...)
+	usedSet := attribute.NewSet(append(attrs, semconv.DBClientConnectionStateUsed)...)
+	return meter.RegisterCallback(func(ctx context.Context, observer metric.Observer) error {
+		s := stats()
+		observer.ObserveInt64(m.count, s.Idle, metric.WithAttributeSet(idleSet))
+		observer.ObserveInt64(m.count, s.Used, metric.WithAttributeSet(usedSet))
+		// the pools without a limit do not report the max
+		if s.Max > 0 {
+			observer.ObserveInt64(m.max, s.Max, metric.WithAttributeSet(set))
+		}
+		if s.IdleMax >= 0 {
+			observer.ObserveInt64(m.idleMax, s.IdleMax, metric.WithAttributeSet(set))
+		}
+		if s.Timeouts >= 0 {
+			observer.ObserveInt64(m.timeouts, s.Timeouts, metric.WithAttributeSet(set))
+		}
+		if s.WaitTime >= 0 {
+			observer.ObserveFloat64(m.totalWaitTime, utils.DurationValue(s.WaitTime), metric.WithAttributeSet(set))
+		}
+		return nil
+	}, m.count, m.max, m.idleMax, m.timeouts, m.totalWaitTime)
+}
+
+// DbPoolTimes records db.client.connection.wait_time and
+// db.client.connection.use_time for every connection acquired from a pool,
+// it is used by the instrumentations that see the connections being acquired
+// and released.
+type DbPoolTimes struct {
+	waitTime metric.Float64Histogram
+	useTime  metric.Float64Histogram
+	set      metric.MeasurementOption
+}
+
+// NewDbPoolTimes records the times with the same attributes as
+// RegisterDbPoolMetrics.
+func NewDbPoolTimes(poolName string, serverAddress string) (*DbPoolTimes, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	if err := initPoolMetric(); err != nil {
+		return nil, err
+	}
+	return newDbPoolTimes(poolMetric, poolName, serverAddress), nil
+}
+
+func newDbPoolTimes(m *dbPoolMetric, poolName string, serverAddress string) *DbPoolTimes {
+	return &DbPoolTimes{
+		waitTime: m.waitTime,
+		useTime:  m.useTime,
+		set:      metric.WithAttributeSet(attribute.NewSet(poolAttributes(poolName, serverAddress)...)),
+	}
+}
+
+// RecordWaitTime records the time it took to obtain a connection.
+func (t *DbPoolTimes) RecordWaitTime(ctx context.Context, d time.Duration) {
+	t.waitTime.Record(ctx, utils.DurationValue(d), t.set)
+}
+
+// RecordUseTime records the time a connection was borrowed for.
+func (t *DbPoolTimes) RecordUseTime(ctx context.Context, d time.Duration) {
+	t.useTime.Record(ctx, utils.DurationValue(d), t.set)
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api-semconv/instrumenter/utils"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+)
+
+func TestDbPoolMetrics(t *testing.T) {
+	reader := metric.NewManualReader()
+	mp := metric.NewMeterProvider(metric.WithReader(reader))
+	InitDbMetrics(mp.Meter("test-meter"))
+	defer func() {
+		InitDbMetrics(nil)
+		poolMetric = nil
+	}()
+	reg, err := RegisterDbPoolMetrics("127.0.0.1:3306/shop", "127.0.0.1", func() DbPoolStats {
+		return DbPoolStats{Idle: 2, Used: 3, Max: 10, IdleMax: 2, Timeouts: 4, WaitTime: 1500 * time.Millisecond}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rm := metricdata.ResourceMetrics{}
+	if err = reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	values := map[string]int64{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		switch data := m.Data.(type) {
+		case metricdata.Sum[int64]:
+			for _, dp := range data.DataPoints {
+				if name, _ := dp.Attributes.Value(semconv.DBClientConnectionPoolNameKey); name.AsString() != "127.0.0.1:3306/shop" {
+					t.Fatalf("unexpected pool name %v", dp.Attributes)
+				}
+				state, _ := dp.Attributes.Value(semconv.DBClientConnectionStateKey)
+				values[m.Name+state.AsString()] = dp.Value
+			}
+		case metricdata.Sum[float64]:
+			if !data.IsMonotonic || data.DataPoints[0].Value <= 0 {
+				t.Fatalf("unexpected wait time %v", data)
+			}
+			values[m.Name] = 1
+		}
+	}
+	expected := map[string]int64{
+		"db.client.connection.countidle":       2,
+		"db.client.connection.countused":       3,
+		"db.client.connection.max":             10,
+		"db.client.connection.idle.max":        2,
+		"db.client.connection.timeouts":        4,
+		"db.client.connection.total_wait_time": 1,
+	}
+	for name, value := range expected {
+		if values[name] != value {
+			t.Fatalf("expected %s to be %d, got %v", name, value, values)
+		}
+	}
+
+	if err = reg.Unregister(); err != nil {
+		t.Fatal(err)
+	}
+	rm = metricdata.ResourceMetrics{}
+	if err = reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if sum, ok := m.Data.(metricdata.Sum[int64]); ok && len(sum.DataPoints) > 0 {
+				t.Fatalf("expected no observation after unregister, got %v", m)
+			}
+		}
+	}
+}
+
+func TestDbPoolMetricsUnknownStats(t *testing.T) {
+	reader := metric.NewManualReader()
+	mp := metric.NewMeterProvider(metric.WithReader(reader))
+	InitDbMetrics(mp.Meter("test-meter"))
+	defer func() {
+		InitDbMetrics(nil)
+		poolMetric = nil
+	}()
+	_, err := RegisterDbPoolMetrics("127.0.0.1:6379", "127.0.0.1", func() DbPoolStats {
+		return DbPoolStats{Idle: 1, IdleMax: -1, Timeouts: -1, WaitTime: -1}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rm := metricdata.ResourceMetrics{}
+	if err = reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		switch m.Name {
+		case "db.client.connection.max", "db.client.connection.idle.max", "db.client.connection.timeouts":
+			if sum, ok := m.Data.(metricdata.Sum[int64]); ok && len(sum.DataPoints) > 0 {
+				t.Fatalf("expected %s not to be observed, got %v", m.Name, sum.DataPoints)
+			}
+		case "db.client.connection.total_wait_time":
+			if sum, ok := m.Data.(metricdata.Sum[float64]); ok && len(sum.DataPoints) > 0 {
+				t.Fatalf("expected %s not to be observed, got %v", m.Name, sum.DataPoints)
+			}
+		}
+	}
+}
+
+func TestDbPoolTimes(t *testing.T) {
+	reader := metric.NewManualReader()
+	mp := metric.NewMeterProvider(metric.WithReader(reader))
+	InitDbMetrics(mp.Meter("test-meter"))
+	defer func() {
+		InitDbMetrics(nil)
+		poolMetric = nil
+	}()
+	times, err := NewDbPoolTimes("127.0.0.1:5432/users", "127.0.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	times.RecordWaitTime(context.Background(), 20*time.Millisecond)
+	times.RecordWaitTime(context.Background(), 40*time.Millisecond)
+	times.RecordUseTime(context.Background(), 2*time.Second)
+
+	rm := metricdata.ResourceMetrics{}
+	if err = reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	counts := map[string]uint64{}
+	sums := map[string]float64{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		if data, ok := m.Data.(metricdata.Histogram[float64]); ok {
+			for _, dp := range data.DataPoints {
+				if name, _ := dp.Attributes.Value(semconv.DBClientConnectionPoolNameKey); name.AsString() != "127.0.0.1:5432/users" {
+					t.Fatalf("unexpected pool name %v", dp.Attributes)
+				}
+				counts[m.Name] += dp.Count
+				sums[m.Name] += dp.Sum
+			}
+		}
+	}
+	if counts["db.client.connection.wait_time"] != 2 || counts["db.client.connection.use_time"] != 1 {
+		t.Fatalf("unexpected histogram counts %v", counts)
+	}
+	if sums["db.client.connection.use_time"] != utils.DurationValue(2*time.Second) {
+		t.Fatalf("unexpected histogram sums %v", sums)
+	}
+}
+
+func TestDbPoolName(t *testing.T) {
+	first := DbPoolName("127.0.0.1:3306/orders")
+	second := DbPoolName("127.0.0.1:3306/orders")
+	if first != "127.0.0.1:3306/orders" || second != "127.0.0.1:3306/orders#2" {
+		t.Fatalf("unexpected pool names %s %s", first, second)
+	}
+}
+
+func TestDbPoolMetricsWithoutMeter(t *testing.T) {
+	InitDbMetrics(nil)
+	if _, err := RegisterDbPoolMetrics("pool", "", func() DbPoolStats { return DbPoolStats{} }); err == nil {
+		t.Fatal("expected an error without meter")
+	}
+	if _, err := NewDbPoolTimes("pool", ""); err == nil {
+		t.Fatal("expected an error without meter")
+	}
+}
@@ -0,0 +1,287 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// set to "false" to record the statements as they are
+	EnvDBStatementSanitizerEnabled = "OTEL_INSTRUMENTATION_DB_STATEMENT_SANITIZER_ENABLED"
+	// set to "true" to record the bound parameters as db.query.parameter.<n>
+	EnvDBCaptureParameters = "OTEL_INSTRUMENTATION_DB_CAPTURE_PARAMETERS"
+)
+
+var statementSanitizerEnabled = envBool(EnvDBStatementSanitizerEnabled, true)
+
+var parameterCaptureEnabled = envBool(EnvDBCaptureParameters, false)
+
+func envBool(name string, defaultValue bool) bool {
+	val, err := strconv.ParseBool(os.Getenv(name))
+	if err != nil {
+		return defaultValue
+	}
+	return val
+}
+
+// DbSanitizedStatementGetter is implemented by the getters caching the
+// sanitized statements, the extractor sanitizes GetStatement otherwise.
+type DbSanitizedStatementGetter[REQUEST any] interface {
+	GetSanitizedStatement(REQUEST) string
+}
+
+// StatementSanitizerEnabled reports whether db.query.text is sanitized.
+func StatementSanitizerEnabled() bool {
+	return statementSanitizerEnabled
+}
+
+// SanitizeStatement masks the literals of the statement by the db system,
+// the statements of unknown systems are treated as sql.
+func SanitizeStatement(system string, statement string) string {
+	switch system {
+	case "redis", "valkey":
+		return SanitizeRedis(statement)
+	case "mongodb":
+		return SanitizeMongo(statement)
+	case "elasticsearch":
+		// the statement is the request path
+		return statement
+	default:
+		// mysql accepts double quoted strings, they are identifiers elsewhere
+		return SanitizeSQL(statement, system == "mysql" || system == "mariadb")
+	}
+}
+
+var inListPattern = regexp.MustCompile(`(?i)\b(IN)\s*\(\s*\?(?:\s*,\s*\?)*\s*\)`)
+
+// SanitizeSQL replaces the string and numeric literals with ?, strips the
+// comments, collapses the whitespaces and the IN lists. The placeholders
+// like ?, $1, :name or @p1 are kept.
+func SanitizeSQL(sql string, doubleQuotedStrings bool) string {
+	b := make([]byte, 0, len(sql))
+	space := false
+	// writeByte collapses the whitespaces into one
+	writeByte := func(c byte) {
+		if space && len(b) > 0 {
+			b = append(b, ' ')
+		}
+		space = false
+		b = append(b, c)
+	}
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f':
+			space = true
+		case c == '-' && i+1 < len(sql) && sql[i+1] == '-':
+			end := strings.IndexByte(sql[i:], '\n')
+			if end < 0 {
+				i = len(sql)
+			} else {
+				i += end
+			}
+			space = true
+		case c == '/' && i+1 < len(sql) && sql[i+1] == '*':
+			end := strings.Index(sql[i+2:], "*/")
+			if end < 0 {
+				i = len(sql)
+			} else {
+				i += end + 3
+			}
+			space = true
+		case c == '\'' || (c == '"' && doubleQuotedStrings):
+			i = skipQuoted(sql, i, c)
+			writeByte('?')
+		case c == '"' || c == '`':
+			// quoted identifiers are kept
+			end := skipQuoted(sql, i, c)
+			for j := i; j <= end && j < len(sql); j++ {
+				writeByte(sql[j])
+			}
+			i = end
+		case c == '$':
+			if end, ok := skipDollarQuoted(sql, i); ok {
+				i = end
+				writeByte('?')
+			} else {
+				writeByte(c)
+			}
+		case isDigit(c) && (len(b) == 0 || space || !isIdentByte(b[len(b)-1])) && !isPlaceholder(b, space):
+			i = skipNumber(sql, i)
+			writeByte('?')
+		default:
+			writeByte(c)
+		}
+	}
+	return inListPattern.ReplaceAllString(string(b), "$1 (?)")
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || isDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+// isPlaceholder reports whether the number is the index of $1 or :1
+func isPlaceholder(b []byte, space bool) bool {
+	if space || len(b) == 0 {
+		return false
+	}
+	last := b[len(b)-1]
+	return last == '$' || last == ':' || last == '@'
+}
+
+// skipQuoted returns the index of the closing quote, a doubled quote or a
+// backslash escapes it.
+func skipQuoted(s string, start int, quote byte) int {
+	for i := start + 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case quote:
+			if i+1 < len(s) && s[i+1] == quote {
+				i++
+				continue
+			}
+			return i
+		}
+	}
+	return len(s) - 1
+}
+
+// skipDollarQuoted skips the postgres $tag$...$tag$ strings.
+func skipDollarQuoted(s string, start int) (int, bool) {
+	end := start + 1
+	for end < len(s) && s[end] != '$' {
+		if !isIdentByte(s[end]) || (end == start+1 && isDigit(s[end])) {
+			return start, false
+		}
+		end++
+	}
+	if end >= len(s) {
+		return start, false
+	}
+	tag := s[start : end+1]
+	closing := strings.Index(s[end+1:], tag)
+	if closing < 0 {
+		return len(s) - 1, true
+	}
+	return end + closing + len(tag), true
+}
+
+func skipNumber(s string, start int) int {
+	i := start
+	if i+1 < len(s) && s[i] == '0' && (s[i+1] == 'x' || s[i+1] == 'X') {
+		i += 2
+		for i < len(s) && (isDigit(s[i]) || (s[i]|0x20 >= 'a' && s[i]|0x20 <= 'f')) {
+			i++
+		}
+		return i - 1
+	}
+	for i < len(s) && (isDigit(s[i]) || s[i] == '.') {
+		i++
+	}
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		j := i + 1
+		if j < len(s) && (s[j] == '+' || s[j] == '-') {
+			j++
+		}
+		if j < len(s) && isDigit(s[j]) {
+			i = j
+			for i < len(s) && isDigit(s[i]) {
+				i++
+			}
+		}
+	}
+	return i - 1
+}
+
+// the commands whose arguments are all sensitive
+var redisCredentialCommands = map[string]bool{
+	"AUTH":  true,
+	"HELLO": true,
+}
+
+// SanitizeRedis keeps the command and its first argument, which is the key
+// of most commands, and masks the rest including the appended results.
+func SanitizeRedis(statement string) string {
+	fields := strings.Fields(statement)
+	if len(fields) == 0 {
+		return statement
+	}
+	keep := 2
+	if redisCredentialCommands[strings.ToUpper(fields[0])] {
+		keep = 1
+	}
+	for i := keep; i < len(fields); i++ {
+		fields[i] = "?"
+	}
+	return strings.Join(fields, " ")
+}
+
+// SanitizeMongo replaces the values of a json like statement with ?, the
+// keys and the operators are kept.
+func SanitizeMongo(statement string) string {
+	b := make([]byte, 0, len(statement))
+	for i := 0; i < len(statement); i++ {
+		c := statement[i]
+		switch {
+		case c == '"' || c == '\'':
+			end := skipQuoted(statement, i, c)
+			next := end + 1
+			for next < len(statement) && (statement[next] == ' ' || statement[next] == '\t' || statement[next] == '\n' || statement[next] == '\r') {
+				next++
+			}
+			if next < len(statement) && statement[next] == ':' {
+				b = append(b, statement[i:end+1]...)
+			} else {
+				b = append(b, '?')
+			}
+			i = end
+		case (isDigit(c) || c == '-') && (len(b) == 0 || !isIdentByte(b[len(b)-1])):
+			end := i
+			if c == '-' {
+				end++
+			}
+			if end >= len(statement) || !isDigit(statement[end]) {
+				b = append(b, c)
+				continue
+			}
+			i = skipNumber(statement, end)
+			b = append(b, '?')
+		case isIdentByte(c) && (len(b) == 0 || !isIdentByte(b[len(b)-1])):
+			end := i
+			for end < len(statement) && isIdentByte(statement[end]) {
+				end++
+			}
+			switch word := statement[i:end]; word {
+			case "true", "false", "null":
+				b = append(b, '?')
+			default:
+				b = append(b, word...)
+			}
+			i = end - 1
+		default:
+			b = append(b, c)
+		}
+	}
+	return string(b)
+}
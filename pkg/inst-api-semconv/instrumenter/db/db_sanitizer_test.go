@@ -0,0 +1,110 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+import (
+	"context"
+	"testing"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+)
+
+func TestSanitizeSQL(t *testing.T) {
+	for sql, expected := range map[string]string{
+		"SELECT * FROM users WHERE name = 'it''s' AND age > 18":                     "SELECT * FROM users WHERE name = ? AND age > ?",
+		"select *\n  from t1 -- by id\n where id in (1, 2,3) /* hint */ limit 10":   "select * from t1 where id in (?) limit ?",
+		"INSERT INTO `t2` (a, \"b\") VALUES ($1, :name, @p1, ?)":                    "INSERT INTO `t2` (a, \"b\") VALUES ($1, :name, @p1, ?)",
+		"UPDATE t SET v = 0x1F, f = 1.5e-3, s = $tag$secret$tag$ WHERE k = 'a\\'b'": "UPDATE t SET v = ?, f = ?, s = ? WHERE k = ?",
+	} {
+		if got := SanitizeSQL(sql, false); got != expected {
+			t.Errorf("SanitizeSQL(%q) = %q; expected %q", sql, got, expected)
+		}
+	}
+	if got := SanitizeStatement("mysql", `SELECT "secret" FROM t`); got != "SELECT ? FROM t" {
+		t.Errorf("expected the double quoted mysql string to be masked, got %q", got)
+	}
+	if got := SanitizeStatement("postgresql", `SELECT "Name" FROM t`); got != `SELECT "Name" FROM t` {
+		t.Errorf("expected the quoted identifier to be kept, got %q", got)
+	}
+}
+
+func TestSanitizeRedis(t *testing.T) {
+	for statement, expected := range map[string]string{
+		"set user:1 alice ex 10": "set user:1 ? ? ?",
+		"get user:1: alice":      "get user:1: ?",
+		"AUTH default password":  "AUTH ? ?",
+		"ping":                   "ping",
+	} {
+		if got := SanitizeStatement("redis", statement); got != expected {
+			t.Errorf("SanitizeRedis(%q) = %q; expected %q", statement, got, expected)
+		}
+	}
+}
+
+func TestSanitizeMongo(t *testing.T) {
+	for statement, expected := range map[string]string{
+		`{"name": "alice", "age": {"$gt": 18}, "tags": ["a", -1.5, true]}`: `{"name": ?, "age": {"$gt": ?}, "tags": [?, ?, ?]}`,
+		"find": "find",
+	} {
+		if got := SanitizeStatement("mongodb", statement); got != expected {
+			t.Errorf("SanitizeMongo(%q) = %q; expected %q", statement, got, expected)
+		}
+	}
+}
+
+type sqlAttrsGetter struct {
+	mongoAttrsGetter
+	statement string
+}
+
+func (s sqlAttrsGetter) GetSystem(request testRequest) string {
+	return "mysql"
+}
+
+func (s sqlAttrsGetter) GetStatement(request testRequest) string {
+	return s.statement
+}
+
+func (s sqlAttrsGetter) GetParameters(request testRequest) []any {
+	return []any{"alice"}
+}
+
+func TestExtractorSanitizesStatement(t *testing.T) {
+	extractor := DbClientAttrsExtractor[testRequest, testResponse, sqlAttrsGetter]{}
+	extractor.Base.Getter = sqlAttrsGetter{statement: "SELECT * FROM users WHERE name = 'alice'"}
+	attrs, _ := extractor.OnEnd(nil, context.Background(), testRequest{}, testResponse{}, nil)
+	for _, attr := range attrs {
+		if attr.Key == semconv.DBQueryTextKey && attr.Value.AsString() != "SELECT * FROM users WHERE name = ?" {
+			t.Fatalf("expected the statement to be sanitized, got %s", attr.Value.AsString())
+		}
+		if attr.Key == "db.query.parameter.0" {
+			t.Fatal("expected the parameters not to be captured by default")
+		}
+	}
+
+	defer func() { statementSanitizerEnabled, parameterCaptureEnabled = true, false }()
+	statementSanitizerEnabled, parameterCaptureEnabled = false, true
+	attrs, _ = extractor.OnEnd(nil, context.Background(), testRequest{}, testResponse{}, nil)
+	captured := false
+	for _, attr := range attrs {
+		if attr.Key == semconv.DBQueryTextKey && attr.Value.AsString() != extractor.Base.Getter.statement {
+			t.Fatalf("expected the raw statement, got %s", attr.Value.AsString())
+		}
+		captured = captured || attr.Key == "db.query.parameter.0"
+	}
+	if !captured {
+		t.Fatal("expected the parameters to be captured")
+	}
+}
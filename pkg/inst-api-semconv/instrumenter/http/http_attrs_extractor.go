@@ -82,6 +82,9 @@ func (h *HttpClientAttrsExtractor[REQUEST, RESPONSE, GETTER1, GETTER2]) OnStart(
 		Key:   semconv.ServerPortKey,
 		Value: attribute.IntValue(h.Base.HttpGetter.GetServerPort(request)),
 	})
+	attributes = clientHeaderCapture.appendRequestHeaders(attributes, func(name string) []string {
+		return h.Base.HttpGetter.GetHttpRequestHeader(request, name)
+	})
 	if h.Base.AttributesFilter != nil {
 		attributes = h.Base.AttributesFilter(attributes)
 	}
@@ -91,6 +94,11 @@ func (h *HttpClientAttrsExtractor[REQUEST, RESPONSE, GETTER1, GETTER2]) OnStart(
 func (h *HttpClientAttrsExtractor[REQUEST, RESPONSE, GETTER1, GETTER2]) OnEnd(attributes []attribute.KeyValue, context context.Context, request REQUEST, response RESPONSE, err error) ([]attribute.KeyValue, context.Context) {
 	attributes, context = h.Base.OnEnd(attributes, context, request, response, err)
 	attributes, context = h.NetworkExtractor.OnEnd(attributes, context, request, response, err)
+	attributes = clientHeaderCapture.appendResponseHeaders(attributes, func(name string) []string {
+		return h.Base.HttpGetter.GetHttpResponseHeader(request, response, name)
+	})
+	attributes = appendBodySizes(attributes, h.Base.HttpGetter, request, response)
+	recordBodies(context, h.Base.HttpGetter, request, response)
 	if h.Base.AttributesFilter != nil {
 		attributes = h.Base.AttributesFilter(attributes)
 	}
@@ -121,6 +129,9 @@ func (h *HttpServerAttrsExtractor[REQUEST, RESPONSE, GETTER1, GETTER2, GETTER3])
 		Key:   semconv.UserAgentOriginalKey,
 		Value: attribute.StringValue(firstUserAgent),
 	})
+	attributes = serverHeaderCapture.appendRequestHeaders(attributes, func(name string) []string {
+		return h.Base.HttpGetter.GetHttpRequestHeader(request, name)
+	})
 	if h.Base.AttributesFilter != nil {
 		attributes = h.Base.AttributesFilter(attributes)
 	}
@@ -133,7 +144,12 @@ func (h *HttpServerAttrsExtractor[REQUEST, RESPONSE, GETTER1, GETTER2, GETTER3])
 	attributes, context = h.NetworkExtractor.OnEnd(attributes, context, request, response, err)
 	span := trace.SpanFromContext(context)
 	localRootSpan, ok := span.(sdktrace.ReadOnlySpan)
-	if ok && span.IsRecording() {
+	if route, refined := getHttpRoute(context); refined {
+		attributes = append(attributes, attribute.KeyValue{
+			Key:   semconv.HTTPRouteKey,
+			Value: attribute.StringValue(route),
+		})
+	} else if ok && span.IsRecording() {
 		route := h.Base.HttpGetter.GetHttpRoute(request)
 		if !strings.Contains(localRootSpan.Name(), route) {
 			route = localRootSpan.Name()
@@ -143,6 +159,11 @@ func (h *HttpServerAttrsExtractor[REQUEST, RESPONSE, GETTER1, GETTER2, GETTER3])
 			Value: attribute.StringValue(route),
 		})
 	}
+	attributes = serverHeaderCapture.appendResponseHeaders(attributes, func(name string) []string {
+		return h.Base.HttpGetter.GetHttpResponseHeader(request, response, name)
+	})
+	attributes = appendBodySizes(attributes, h.Base.HttpGetter, request, response)
+	recordBodies(context, h.Base.HttpGetter, request, response)
 	if h.Base.AttributesFilter != nil {
 		attributes = h.Base.AttributesFilter(attributes)
 	}
@@ -152,3 +173,17 @@ func (h *HttpServerAttrsExtractor[REQUEST, RESPONSE, GETTER1, GETTER2, GETTER3])
 func (h *HttpServerAttrsExtractor[REQUEST, RESPONSE, GETTER1, GETTER2, GETTER3]) GetSpanKey() attribute.Key {
 	return utils.HTTP_SERVER_KEY
 }
+
+func appendBodySizes[REQUEST any, RESPONSE any](attributes []attribute.KeyValue, getter any, request REQUEST, response RESPONSE) []attribute.KeyValue {
+	sizeGetter, ok := getter.(HttpBodySizeGetter[REQUEST, RESPONSE])
+	if !ok {
+		return attributes
+	}
+	if size := sizeGetter.GetHttpRequestBodySize(request); size >= 0 {
+		attributes = append(attributes, semconv.HTTPRequestBodySize(int(size)))
+	}
+	if size := sizeGetter.GetHttpResponseBodySize(request, response); size >= 0 {
+		attributes = append(attributes, semconv.HTTPResponseBodySize(int(size)))
+	}
+	return attributes
+}
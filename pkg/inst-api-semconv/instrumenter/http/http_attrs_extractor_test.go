@@ -443,3 +443,29 @@ func TestNonRecordingSpan(t *testing.T) {
 		t.Fatalf("wrong network peer port")
 	}
 }
+
+type sizedServerAttrsGetter struct {
+	httpServerAttrsGetter
+}
+
+func (s sizedServerAttrsGetter) GetHttpRequestBodySize(request testRequest) int64 {
+	return -1
+}
+
+func (s sizedServerAttrsGetter) GetHttpResponseBodySize(request testRequest, response testResponse) int64 {
+	return 128
+}
+
+func TestHttpServerExtractorBodySize(t *testing.T) {
+	httpServerExtractor := HttpServerAttrsExtractor[testRequest, testResponse, HttpServerAttrsGetter[testRequest, testResponse], networkAttrsGetter, urlAttrsGetter]{}
+	httpServerExtractor.Base.HttpGetter = sizedServerAttrsGetter{}
+	attrs, _ := httpServerExtractor.OnEnd(nil, context.Background(), testRequest{}, testResponse{}, nil)
+	for _, attr := range attrs {
+		if attr.Key == semconv.HTTPRequestBodySizeKey {
+			t.Fatal("expected the unknown request body size to be skipped")
+		}
+	}
+	if v, ok := findAttribute(attrs, semconv.HTTPResponseBodySizeKey); !ok || v.AsInt64() != 128 {
+		t.Fatalf("expected the response body size, got %v", attrs)
+	}
+}
@@ -41,3 +41,11 @@ type HttpClientAttrsGetter[REQUEST any, RESPONSE any] interface {
 	GetServerAddress(request REQUEST) string
 	GetServerPort(request REQUEST) int
 }
+
+// HttpBodySizeGetter is optionally implemented by the http attrs getters, the
+// sizes are recorded as attributes and body size metrics, a negative size
+// means that the size is unknown.
+type HttpBodySizeGetter[REQUEST any, RESPONSE any] interface {
+	GetHttpRequestBodySize(request REQUEST) int64
+	GetHttpResponseBodySize(request REQUEST, response RESPONSE) int64
+}
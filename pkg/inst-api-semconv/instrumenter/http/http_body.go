@@ -0,0 +1,423 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	nethttp "net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// set to "true" to record the http bodies as span events
+	captureBodyEnv = "OTEL_INSTRUMENTATION_HTTP_CAPTURE_BODY"
+	// the max bytes recorded per body, the rest is dropped
+	captureBodyMaxSizeEnv = "OTEL_INSTRUMENTATION_HTTP_CAPTURE_BODY_MAX_SIZE"
+	// the comma separated media types whose bodies are recorded, "type/*"
+	// matches every subtype
+	captureBodyContentTypesEnv = "OTEL_INSTRUMENTATION_HTTP_CAPTURE_BODY_CONTENT_TYPES"
+	// the comma separated json paths whose values are redacted, e.g.
+	// "$.password", "$.users[*].token" or "$..secret"
+	captureBodyRedactPathsEnv = "OTEL_INSTRUMENTATION_HTTP_CAPTURE_BODY_REDACT_PATHS"
+)
+
+const (
+	defaultBodyMaxSize      = 4096
+	defaultBodyContentTypes = "application/json,application/x-www-form-urlencoded,application/xml,text/plain,text/xml"
+	defaultBodyRedactPaths  = "$..password,$..passwd,$..secret,$..token,$..access_token,$..refresh_token"
+)
+
+// the bodies of these media types are streamed and never buffered
+var streamingContentTypes = []string{
+	"text/event-stream",
+	"application/x-ndjson",
+	"application/stream+json",
+	"application/grpc",
+	"multipart/",
+}
+
+const (
+	requestBodyEventName  = "http.request.body"
+	responseBodyEventName = "http.response.body"
+)
+
+// HttpBodyGetter is implemented by the http attrs getters supporting body
+// capture, the extractors record the returned bodies as span events on end.
+type HttpBodyGetter[REQUEST any, RESPONSE any] interface {
+	GetHttpRequestBody(request REQUEST) *BodyBuffer
+	GetHttpResponseBody(request REQUEST, response RESPONSE) *BodyBuffer
+}
+
+type bodyCapture struct {
+	enabled      bool
+	maxSize      int
+	contentTypes []string
+	redactPaths  [][]string
+	// the key names redacted when the json body can not be decoded
+	redactKeys *regexp.Regexp
+}
+
+var bodyCaptureConfig = newBodyCaptureFromEnv()
+
+func newBodyCaptureFromEnv() *bodyCapture {
+	c := &bodyCapture{
+		enabled: os.Getenv(captureBodyEnv) == "true",
+		maxSize: defaultBodyMaxSize,
+	}
+	if size, err := strconv.Atoi(os.Getenv(captureBodyMaxSizeEnv)); err == nil && size > 0 {
+		c.maxSize = size
+	}
+	contentTypes := os.Getenv(captureBodyContentTypesEnv)
+	if contentTypes == "" {
+		contentTypes = defaultBodyContentTypes
+	}
+	for _, contentType := range strings.Split(contentTypes, ",") {
+		if contentType = strings.ToLower(strings.TrimSpace(contentType)); contentType != "" {
+			c.contentTypes = append(c.contentTypes, contentType)
+		}
+	}
+	redactPaths, ok := os.LookupEnv(captureBodyRedactPathsEnv)
+	if !ok {
+		redactPaths = defaultBodyRedactPaths
+	}
+	var keys []string
+	for _, path := range strings.Split(redactPaths, ",") {
+		segments := parseJsonPath(strings.TrimSpace(path))
+		if len(segments) == 0 {
+			continue
+		}
+		c.redactPaths = append(c.redactPaths, segments)
+		if last := segments[len(segments)-1]; last != "*" && last != ".." {
+			keys = append(keys, regexp.QuoteMeta(last))
+		}
+	}
+	if len(keys) > 0 {
+		c.redactKeys = regexp.MustCompile(`("(?:` + strings.Join(keys, "|") + `)"\s*:\s*)("(?:[^"\\]|\\.)*"?|[^,}\]\s]*)`)
+	}
+	return c
+}
+
+// parseJsonPath splits "$.a[*]..b" into ["a", "*", "..", "b"], array
+// indexes are matched like keys.
+func parseJsonPath(path string) []string {
+	path = strings.TrimPrefix(path, "$")
+	var segments []string
+	for len(path) > 0 {
+		switch {
+		case strings.HasPrefix(path, ".."):
+			segments = append(segments, "..")
+			path = path[2:]
+		case path[0] == '.':
+			path = path[1:]
+		case path[0] == '[':
+			end := strings.IndexByte(path, ']')
+			if end < 0 {
+				return nil
+			}
+			segments = append(segments, strings.Trim(path[1:end], `'"`))
+			path = path[end+1:]
+		default:
+			end := strings.IndexAny(path, ".[")
+			if end < 0 {
+				end = len(path)
+			}
+			segments = append(segments, path[:end])
+			path = path[end:]
+		}
+	}
+	if len(segments) > 0 && segments[len(segments)-1] == ".." {
+		return nil
+	}
+	return segments
+}
+
+// allowed reports whether the bodies of the content type are captured.
+func (c *bodyCapture) allowed(contentType string) bool {
+	if !c.enabled || contentType == "" {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, streaming := range streamingContentTypes {
+		if strings.HasPrefix(mediaType, streaming) {
+			return false
+		}
+	}
+	for _, allowed := range c.contentTypes {
+		if allowed == mediaType || (strings.HasSuffix(allowed, "/*") && strings.HasPrefix(mediaType, allowed[:len(allowed)-1])) {
+			return true
+		}
+	}
+	return false
+}
+
+// BodyCaptureEnabled reports whether the http bodies are captured at all, the
+// rules check it before wrapping any body.
+func BodyCaptureEnabled() bool {
+	return bodyCaptureConfig.enabled
+}
+
+// BodyBuffer keeps the first bytes of a body up to the configured max size,
+// a nil buffer captures nothing.
+type BodyBuffer struct {
+	mu          sync.Mutex
+	contentType string
+	limit       int
+	buf         []byte
+	truncated   bool
+	discarded   bool
+}
+
+// NewBodyBuffer returns nil unless the bodies of the content type are
+// captured.
+func NewBodyBuffer(contentType string) *BodyBuffer {
+	if !bodyCaptureConfig.allowed(contentType) {
+		return nil
+	}
+	return &BodyBuffer{contentType: contentType, limit: bodyCaptureConfig.maxSize}
+}
+
+// CaptureBody copies the body, which is already fully in memory, e.g. the
+// fasthttp and hertz bodies.
+func CaptureBody(contentType string, body []byte) *BodyBuffer {
+	b := NewBodyBuffer(contentType)
+	b.Write(body)
+	return b
+}
+
+func (b *BodyBuffer) Write(p []byte) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.discarded || b.truncated {
+		return
+	}
+	if remain := b.limit - len(b.buf); len(p) > remain {
+		p = p[:remain]
+		b.truncated = true
+	}
+	b.buf = append(b.buf, p...)
+}
+
+// Discard drops the captured bytes, it is called once the body turns out to
+// be streamed.
+func (b *BodyBuffer) Discard() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.discarded = true
+	b.buf = nil
+}
+
+func (b *BodyBuffer) content() (string, bool, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.discarded || len(b.buf) == 0 {
+		return "", false, false
+	}
+	return string(b.buf), b.truncated, true
+}
+
+type bodyReader struct {
+	io.ReadCloser
+	buf *BodyBuffer
+}
+
+func (r *bodyReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if n > 0 {
+		r.buf.Write(p[:n])
+	}
+	return n, err
+}
+
+// CaptureBodyReader wraps the body so that the bytes are captured as they
+// are read by the application, nothing is read ahead. Empty bodies are kept
+// as they are, the transport tells them apart from the unknown length ones.
+func CaptureBodyReader(body io.ReadCloser, contentType string) (io.ReadCloser, *BodyBuffer) {
+	if body == nil || body == nethttp.NoBody {
+		return body, nil
+	}
+	buf := NewBodyBuffer(contentType)
+	if buf == nil {
+		return body, nil
+	}
+	return &bodyReader{ReadCloser: body, buf: buf}, buf
+}
+
+// endBodyReader calls onEnd once the body is read to the end, fails or is
+// closed, whichever comes first.
+type endBodyReader struct {
+	io.ReadCloser
+	once  sync.Once
+	onEnd func()
+}
+
+func (r *endBodyReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if err != nil {
+		r.once.Do(r.onEnd)
+	}
+	return n, err
+}
+
+func (r *endBodyReader) Close() error {
+	err := r.ReadCloser.Close()
+	r.once.Do(r.onEnd)
+	return err
+}
+
+// CaptureResponseBodyReader captures the client response body, which is
+// read by the application after the round trip returns. onEnd is called once
+// the body is consumed or closed so that the span ends with the captured
+// bytes, it is never called when the body is not captured and the returned
+// buffer is nil.
+func CaptureResponseBodyReader(body io.ReadCloser, contentType string, onEnd func()) (io.ReadCloser, *BodyBuffer) {
+	reader, buf := CaptureBodyReader(body, contentType)
+	if buf == nil {
+		return body, nil
+	}
+	return &endBodyReader{ReadCloser: reader, onEnd: onEnd}, buf
+}
+
+func recordBody(span trace.Span, name string, b *BodyBuffer) {
+	if b == nil || !span.IsRecording() {
+		return
+	}
+	content, truncated, ok := b.content()
+	if !ok {
+		return
+	}
+	span.AddEvent(name, trace.WithAttributes(
+		attribute.String(name+".content", bodyCaptureConfig.redact(b.contentType, content, truncated)),
+		attribute.Bool(name+".truncated", truncated),
+	))
+}
+
+func recordBodies[REQUEST any, RESPONSE any](ctx context.Context, getter any, request REQUEST, response RESPONSE) {
+	if !bodyCaptureConfig.enabled {
+		return
+	}
+	bodyGetter, ok := getter.(HttpBodyGetter[REQUEST, RESPONSE])
+	if !ok {
+		return
+	}
+	span := trace.SpanFromContext(ctx)
+	recordBody(span, requestBodyEventName, bodyGetter.GetHttpRequestBody(request))
+	recordBody(span, responseBodyEventName, bodyGetter.GetHttpResponseBody(request, response))
+}
+
+// redact replaces the values of the configured json paths, the form values
+// are redacted by the last path segment.
+func (c *bodyCapture) redact(contentType string, content string, truncated bool) string {
+	if len(c.redactPaths) == 0 {
+		return content
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediaType == "application/x-www-form-urlencoded":
+		return c.redactForm(content)
+	case strings.HasSuffix(mediaType, "json"):
+		if !truncated {
+			var v interface{}
+			decoder := json.NewDecoder(strings.NewReader(content))
+			decoder.UseNumber()
+			if err := decoder.Decode(&v); err == nil {
+				for _, path := range c.redactPaths {
+					v = redactJsonPath(v, path)
+				}
+				if redacted, err := json.Marshal(v); err == nil {
+					return string(redacted)
+				}
+			}
+		}
+		if c.redactKeys == nil {
+			return content
+		}
+		return c.redactKeys.ReplaceAllString(content, `${1}"`+redactedHeaderValue+`"`)
+	}
+	return content
+}
+
+func (c *bodyCapture) redactForm(content string) string {
+	// a truncated form is parsed as far as possible
+	values, _ := url.ParseQuery(content)
+	redacted := false
+	for _, path := range c.redactPaths {
+		key := path[len(path)-1]
+		if _, ok := values[key]; ok {
+			values.Set(key, redactedHeaderValue)
+			redacted = true
+		}
+	}
+	if !redacted {
+		return content
+	}
+	return values.Encode()
+}
+
+func redactJsonPath(v interface{}, path []string) interface{} {
+	if len(path) == 0 {
+		return redactedHeaderValue
+	}
+	segment, rest := path[0], path[1:]
+	if segment == ".." {
+		// the rest of the path applies at this level and at any depth below
+		v = redactJsonPath(v, rest)
+		switch node := v.(type) {
+		case map[string]interface{}:
+			for k, child := range node {
+				node[k] = redactJsonPath(child, path)
+			}
+		case []interface{}:
+			for i, child := range node {
+				node[i] = redactJsonPath(child, path)
+			}
+		}
+		return v
+	}
+	switch node := v.(type) {
+	case map[string]interface{}:
+		for k, child := range node {
+			if segment == "*" || segment == k {
+				node[k] = redactJsonPath(child, rest)
+			}
+		}
+	case []interface{}:
+		for i, child := range node {
+			if segment == "*" || segment == strconv.Itoa(i) {
+				node[i] = redactJsonPath(child, rest)
+			}
+		}
+	}
+	return v
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type bodyServerAttrsGetter struct {
+	httpServerAttrsGetter
+	requestBody  *BodyBuffer
+	responseBody *BodyBuffer
+}
+
+func (b bodyServerAttrsGetter) GetHttpRequestBody(request testRequest) *BodyBuffer {
+	return b.requestBody
+}
+
+func (b bodyServerAttrsGetter) GetHttpResponseBody(request testRequest, response testResponse) *BodyBuffer {
+	return b.responseBody
+}
+
+func enableBodyCapture(t *testing.T, maxSize string) {
+	t.Setenv(captureBodyEnv, "true")
+	t.Setenv(captureBodyMaxSizeEnv, maxSize)
+	old := bodyCaptureConfig
+	bodyCaptureConfig = newBodyCaptureFromEnv()
+	t.Cleanup(func() { bodyCaptureConfig = old })
+}
+
+func TestBodyCaptureDisabled(t *testing.T) {
+	t.Setenv(captureBodyEnv, "")
+	old := bodyCaptureConfig
+	defer func() { bodyCaptureConfig = old }()
+	bodyCaptureConfig = newBodyCaptureFromEnv()
+	if BodyCaptureEnabled() || NewBodyBuffer("application/json") != nil {
+		t.Fatal("expected the body capture to be disabled by default")
+	}
+	body := io.NopCloser(strings.NewReader("{}"))
+	if r, buf := CaptureBodyReader(body, "application/json"); r != body || buf != nil {
+		t.Fatal("expected the body not to be wrapped")
+	}
+}
+
+func TestBodyContentTypes(t *testing.T) {
+	enableBodyCapture(t, "")
+	for contentType, expected := range map[string]bool{
+		"application/json; charset=utf-8": true,
+		"text/plain":                      true,
+		"image/png":                       false,
+		"text/event-stream":               false,
+		"multipart/form-data; boundary=x": false,
+		"":                                false,
+	} {
+		if got := NewBodyBuffer(contentType) != nil; got != expected {
+			t.Errorf("NewBodyBuffer(%q) captured = %v; expected %v", contentType, got, expected)
+		}
+	}
+}
+
+func TestBodyRedaction(t *testing.T) {
+	t.Setenv(captureBodyRedactPathsEnv, "$.user.password, $.cards[*].number, $..token")
+	c := newBodyCaptureFromEnv()
+	got := c.redact("application/json", `{"user":{"name":"a","password":"p"},"cards":[{"number":1}],"nested":{"token":"t"}}`, false)
+	expected := `{"cards":[{"number":"[REDACTED]"}],"nested":{"token":"[REDACTED]"},"user":{"name":"a","password":"[REDACTED]"}}`
+	if got != expected {
+		t.Fatalf("expected %s, got %s", expected, got)
+	}
+	// truncated json is redacted by the key names
+	got = c.redact("application/json", `{"user":{"password":"p","token":"abc`, true)
+	if strings.Contains(got, `"p"`) || strings.Contains(got, "abc") {
+		t.Fatalf("expected the truncated json to be redacted, got %s", got)
+	}
+	if got = c.redact("application/x-www-form-urlencoded", "name=a&token=t", false); got != "name=a&token=%5BREDACTED%5D" {
+		t.Fatalf("expected the form to be redacted, got %s", got)
+	}
+	if got = c.redact("text/plain", "token=t", false); got != "token=t" {
+		t.Fatalf("expected the text to be kept, got %s", got)
+	}
+}
+
+func TestBodyReaders(t *testing.T) {
+	enableBodyCapture(t, "4")
+	r, buf := CaptureBodyReader(io.NopCloser(strings.NewReader("hello")), "text/plain")
+	if data, _ := io.ReadAll(r); string(data) != "hello" {
+		t.Fatalf("expected the body to be read as it is, got %s", data)
+	}
+	if content, truncated, ok := buf.content(); !ok || content != "hell" || !truncated {
+		t.Fatalf("unexpected captured body %q, %v", content, truncated)
+	}
+
+	buf = CaptureBody("text/plain", []byte("hi"))
+	buf.Discard()
+	if _, _, ok := buf.content(); ok {
+		t.Fatal("expected the discarded body to be dropped")
+	}
+}
+
+func TestCaptureResponseBodyReader(t *testing.T) {
+	enableBodyCapture(t, "")
+	// the body is written slowly, nothing may wait for it before the
+	// application reads it
+	pr, pw := io.Pipe()
+	ended := make(chan struct{})
+	r, buf := CaptureResponseBodyReader(pr, "application/json", func() { close(ended) })
+	if buf == nil {
+		t.Fatal("expected the response body to be captured")
+	}
+	go func() {
+		_, _ = pw.Write([]byte(`{"id":`))
+		time.Sleep(50 * time.Millisecond)
+		_, _ = pw.Write([]byte(`1}`))
+		_ = pw.Close()
+	}()
+	select {
+	case <-ended:
+		t.Fatal("expected the body not to end before it is read")
+	default:
+	}
+	if data, err := io.ReadAll(r); err != nil || string(data) != `{"id":1}` {
+		t.Fatalf("expected the body to be read as it is, got %s, %v", data, err)
+	}
+	select {
+	case <-ended:
+	case <-time.After(time.Second):
+		t.Fatal("expected the body to end at EOF")
+	}
+	if content, truncated, ok := buf.content(); !ok || content != `{"id":1}` || truncated {
+		t.Fatalf("unexpected captured body %q, %v", content, truncated)
+	}
+	// closing the body after EOF does not end it twice
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// a body closed before EOF ends as well, and the read errors reach the
+	// application
+	pr, pw = io.Pipe()
+	calls := 0
+	r, _ = CaptureResponseBodyReader(pr, "text/plain", func() { calls++ })
+	_ = pw.CloseWithError(errors.New("reset"))
+	if _, err := r.Read(make([]byte, 8)); err == nil || err.Error() != "reset" {
+		t.Fatalf("expected the read error, got %v", err)
+	}
+	_ = r.Close()
+	if calls != 1 {
+		t.Fatalf("expected the body to end once, got %d", calls)
+	}
+
+	body := io.NopCloser(strings.NewReader("image"))
+	if r, buf = CaptureResponseBodyReader(body, "image/png", func() { t.Fatal("unexpected end") }); r != body || buf != nil {
+		t.Fatal("expected the body not to be wrapped")
+	}
+}
+
+func TestRecordBodies(t *testing.T) {
+	enableBodyCapture(t, "")
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	ctx, span := tp.Tracer("test").Start(context.Background(), "GET /users")
+	getter := bodyServerAttrsGetter{
+		requestBody:  CaptureBody("application/json", []byte(`{"password":"p"}`)),
+		responseBody: CaptureBody("text/plain", []byte("ok")),
+	}
+	extractor := HttpServerAttrsExtractor[testRequest, testResponse, HttpServerAttrsGetter[testRequest, testResponse], networkAttrsGetter, urlAttrsGetter]{}
+	extractor.Base.HttpGetter = getter
+	extractor.OnEnd(nil, ctx, testRequest{}, testResponse{}, nil)
+	span.End()
+
+	events := sr.Ended()[0].Events()
+	if len(events) != 2 || events[0].Name != requestBodyEventName || events[1].Name != responseBodyEventName {
+		t.Fatalf("unexpected events %v", events)
+	}
+	if content := events[0].Attributes[0].Value.AsString(); content != `{"password":"[REDACTED]"}` {
+		t.Fatalf("expected the request body to be redacted, got %s", content)
+	}
+	if content := events[1].Attributes[0].Value.AsString(); content != "ok" {
+		t.Fatalf("unexpected response body %s", content)
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// the comma separated header names captured as http.request.header.<name>
+// and http.response.header.<name>
+const (
+	captureServerRequestHeadersEnv  = "OTEL_INSTRUMENTATION_HTTP_CAPTURE_HEADERS_SERVER_REQUEST"
+	captureServerResponseHeadersEnv = "OTEL_INSTRUMENTATION_HTTP_CAPTURE_HEADERS_SERVER_RESPONSE"
+	captureClientRequestHeadersEnv  = "OTEL_INSTRUMENTATION_HTTP_CAPTURE_HEADERS_CLIENT_REQUEST"
+	captureClientResponseHeadersEnv = "OTEL_INSTRUMENTATION_HTTP_CAPTURE_HEADERS_CLIENT_RESPONSE"
+	// set to "false" to capture the sensitive headers as they are
+	redactSensitiveHeadersEnv = "OTEL_INSTRUMENTATION_HTTP_CAPTURE_HEADERS_REDACT_SENSITIVE"
+	// the comma separated header names redacted besides the default ones
+	sensitiveHeadersEnv = "OTEL_INSTRUMENTATION_HTTP_CAPTURE_HEADERS_SENSITIVE"
+)
+
+const redactedHeaderValue = "[REDACTED]"
+
+var defaultSensitiveHeaders = []string{
+	"authorization",
+	"proxy-authorization",
+	"cookie",
+	"set-cookie",
+	"x-api-key",
+	"x-auth-token",
+	"x-csrf-token",
+	"x-xsrf-token",
+}
+
+// headerCapture holds the lower cased names of the headers to capture.
+type headerCapture struct {
+	requestHeaders  []string
+	responseHeaders []string
+	sensitive       map[string]struct{}
+}
+
+var (
+	serverHeaderCapture = newHeaderCaptureFromEnv(captureServerRequestHeadersEnv, captureServerResponseHeadersEnv)
+	clientHeaderCapture = newHeaderCaptureFromEnv(captureClientRequestHeadersEnv, captureClientResponseHeadersEnv)
+)
+
+func newHeaderCaptureFromEnv(requestEnv, responseEnv string) *headerCapture {
+	c := &headerCapture{
+		requestHeaders:  headerNamesFromEnv(requestEnv),
+		responseHeaders: headerNamesFromEnv(responseEnv),
+		sensitive:       make(map[string]struct{}),
+	}
+	if os.Getenv(redactSensitiveHeadersEnv) == "false" {
+		return c
+	}
+	for _, name := range defaultSensitiveHeaders {
+		c.sensitive[name] = struct{}{}
+	}
+	for _, name := range headerNamesFromEnv(sensitiveHeadersEnv) {
+		c.sensitive[name] = struct{}{}
+	}
+	return c
+}
+
+func headerNamesFromEnv(key string) []string {
+	var names []string
+	for _, name := range strings.Split(os.Getenv(key), ",") {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func (c *headerCapture) appendHeaders(attributes []attribute.KeyValue, prefix string, names []string, get func(name string) []string) []attribute.KeyValue {
+	if len(names) == 0 {
+		return attributes
+	}
+	for _, name := range names {
+		values := get(name)
+		if len(values) == 0 {
+			continue
+		}
+		if _, ok := c.sensitive[name]; ok {
+			redacted := make([]string, len(values))
+			for i := range redacted {
+				redacted[i] = redactedHeaderValue
+			}
+			values = redacted
+		}
+		attributes = append(attributes, attribute.StringSlice(prefix+name, values))
+	}
+	return attributes
+}
+
+func (c *headerCapture) appendRequestHeaders(attributes []attribute.KeyValue, get func(name string) []string) []attribute.KeyValue {
+	return c.appendHeaders(attributes, "http.request.header.", c.requestHeaders, get)
+}
+
+func (c *headerCapture) appendResponseHeaders(attributes []attribute.KeyValue, get func(name string) []string) []attribute.KeyValue {
+	return c.appendHeaders(attributes, "http.response.header.", c.responseHeaders, get)
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api-semconv/instrumenter/net"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func findAttribute(attrs []attribute.KeyValue, key attribute.Key) (attribute.Value, bool) {
+	for _, attr := range attrs {
+		if attr.Key == key {
+			return attr.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestCaptureServerHeaders(t *testing.T) {
+	t.Setenv(captureServerRequestHeadersEnv, "X-Request-Id, Authorization")
+	t.Setenv(captureServerResponseHeadersEnv, "content-type")
+	defer func(c *headerCapture) { serverHeaderCapture = c }(serverHeaderCapture)
+	serverHeaderCapture = newHeaderCaptureFromEnv(captureServerRequestHeadersEnv, captureServerResponseHeadersEnv)
+
+	extractor := HttpServerAttrsExtractor[testRequest, testResponse, httpServerAttrsGetter, networkAttrsGetter, urlAttrsGetter]{}
+	attrs, _ := extractor.OnStart(nil, context.Background(), testRequest{})
+	if v, ok := findAttribute(attrs, "http.request.header.x-request-id"); !ok || v.AsStringSlice()[0] != "request-header" {
+		t.Fatalf("expected x-request-id to be captured, got %v", attrs)
+	}
+	if v, ok := findAttribute(attrs, "http.request.header.authorization"); !ok || v.AsStringSlice()[0] != redactedHeaderValue {
+		t.Fatalf("expected authorization to be redacted, got %v", attrs)
+	}
+	attrs, _ = extractor.OnEnd(nil, context.Background(), testRequest{}, testResponse{}, nil)
+	if v, ok := findAttribute(attrs, "http.response.header.content-type"); !ok || v.AsStringSlice()[0] != "response-header" {
+		t.Fatalf("expected content-type to be captured, got %v", attrs)
+	}
+}
+
+func TestCaptureClientHeaders(t *testing.T) {
+	t.Setenv(captureClientRequestHeadersEnv, "authorization,x-tenant")
+	t.Setenv(captureClientResponseHeadersEnv, "set-cookie")
+	t.Setenv(redactSensitiveHeadersEnv, "false")
+	defer func(c *headerCapture) { clientHeaderCapture = c }(clientHeaderCapture)
+	clientHeaderCapture = newHeaderCaptureFromEnv(captureClientRequestHeadersEnv, captureClientResponseHeadersEnv)
+
+	extractor := HttpClientAttrsExtractor[testRequest, testResponse, httpClientAttrsGetter, networkAttrsGetter]{
+		NetworkExtractor: net.NetworkAttrsExtractor[testRequest, testResponse, networkAttrsGetter]{},
+	}
+	attrs, _ := extractor.OnStart(nil, context.Background(), testRequest{})
+	if v, ok := findAttribute(attrs, "http.request.header.authorization"); !ok || v.AsStringSlice()[0] != "request-header" {
+		t.Fatalf("expected authorization to be captured as it is, got %v", attrs)
+	}
+	if _, ok := findAttribute(attrs, "http.request.header.x-tenant"); !ok {
+		t.Fatalf("expected x-tenant to be captured, got %v", attrs)
+	}
+	attrs, _ = extractor.OnEnd(nil, context.Background(), testRequest{}, testResponse{}, nil)
+	if _, ok := findAttribute(attrs, "http.response.header.set-cookie"); !ok {
+		t.Fatalf("expected set-cookie to be captured, got %v", attrs)
+	}
+}
+
+func TestSensitiveHeaders(t *testing.T) {
+	t.Setenv(captureServerRequestHeadersEnv, "x-session")
+	t.Setenv(sensitiveHeadersEnv, "X-Session")
+	c := newHeaderCaptureFromEnv(captureServerRequestHeadersEnv, captureServerResponseHeadersEnv)
+	attrs := c.appendRequestHeaders(nil, func(name string) []string { return []string{"a", "b"} })
+	if len(attrs) != 1 || attrs[0].Value.AsStringSlice()[1] != redactedHeaderValue {
+		t.Fatalf("expected x-session to be redacted, got %v", attrs)
+	}
+	if attrs = c.appendResponseHeaders(nil, func(name string) []string { return []string{"a"} }); len(attrs) != 0 {
+		t.Fatalf("expected no response header, got %v", attrs)
+	}
+}
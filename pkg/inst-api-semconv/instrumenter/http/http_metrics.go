@@ -29,16 +29,31 @@ import (
 
 const http_server_request_duration = "http.server.request.duration"
 
+const http_server_active_requests = "http.server.active_requests"
+
+const http_server_request_body_size = "http.server.request.body.size"
+
+const http_server_response_body_size = "http.server.response.body.size"
+
 const http_client_request_duration = "http.client.request.duration"
 
+const http_client_request_body_size = "http.client.request.body.size"
+
+const http_client_response_body_size = "http.client.response.body.size"
+
 type HttpServerMetric struct {
-	key                   attribute.Key
-	serverRequestDuration metric.Float64Histogram
+	key                    attribute.Key
+	serverRequestDuration  metric.Float64Histogram
+	serverActiveRequests   metric.Int64UpDownCounter
+	serverRequestBodySize  metric.Int64Histogram
+	serverResponseBodySize metric.Int64Histogram
 }
 
 type HttpClientMetric struct {
-	key                   attribute.Key
-	clientRequestDuration metric.Float64Histogram
+	key                    attribute.Key
+	clientRequestDuration  metric.Float64Histogram
+	clientRequestBodySize  metric.Int64Histogram
+	clientResponseBodySize metric.Int64Histogram
 }
 
 var mu sync.Mutex
@@ -55,6 +70,14 @@ var httpMetricsConv = map[attribute.Key]bool{
 	semconv.ServerPortKey:             true,
 }
 
+// the active requests are only known by the start attributes
+var httpActiveRequestsConv = map[attribute.Key]bool{
+	semconv.HTTPRequestMethodKey: true,
+	semconv.URLSchemeKey:         true,
+	semconv.ServerAddressKey:     true,
+	semconv.ServerPortKey:        true,
+}
+
 var globalMeter metric.Meter
 
 // InitHttpMetrics TODO: The init function may be executed after the HttpServerOperationListener() method
@@ -83,14 +106,36 @@ func newHttpServerMetric(key string, meter metric.Meter) (*HttpServerMetric, err
 	m := &HttpServerMetric{
 		key: attribute.Key(key),
 	}
-	d, err := newHttpServerRequestDurationMeasures(meter)
-	if err != nil {
+	if err := m.initMeasures(meter); err != nil {
 		return nil, err
 	}
-	m.serverRequestDuration = d
 	return m, nil
 }
 
+// initMeasures creates the duration histogram first, the other instruments
+// are only created along with it.
+func (h *HttpServerMetric) initMeasures(meter metric.Meter) error {
+	d, err := newHttpServerRequestDurationMeasures(meter)
+	if err != nil {
+		return err
+	}
+	a, err := newHttpServerActiveRequestsMeasures(meter)
+	if err != nil {
+		return err
+	}
+	req, err := newHttpBodySizeMeasures(meter, http_server_request_body_size, "Size of HTTP server request bodies.")
+	if err != nil {
+		return err
+	}
+	resp, err := newHttpBodySizeMeasures(meter, http_server_response_body_size, "Size of HTTP server response bodies.")
+	if err != nil {
+		return err
+	}
+	h.serverActiveRequests, h.serverRequestBodySize, h.serverResponseBodySize = a, req, resp
+	h.serverRequestDuration = d
+	return nil
+}
+
 func newHttpServerRequestDurationMeasures(meter metric.Meter) (metric.Float64Histogram, error) {
 	mu.Lock()
 	defer mu.Unlock()
@@ -98,8 +143,7 @@ func newHttpServerRequestDurationMeasures(meter metric.Meter) (metric.Float64His
 		return nil, errors.New("nil meter")
 	}
 	d, err := meter.Float64Histogram(http_server_request_duration,
-		metric.WithUnit("ms"),
-		metric.WithDescription("Duration of HTTP server requests."))
+		utils.DurationHistogramOptions("Duration of HTTP server requests.")...)
 	if err == nil {
 		return d, nil
 	} else {
@@ -112,14 +156,30 @@ func newHttpClientMetric(key string, meter metric.Meter) (*HttpClientMetric, err
 	m := &HttpClientMetric{
 		key: attribute.Key(key),
 	}
-	d, err := newHttpClientRequestDurationMeasures(meter)
-	if err != nil {
+	if err := m.initMeasures(meter); err != nil {
 		return nil, err
 	}
-	m.clientRequestDuration = d
 	return m, nil
 }
 
+func (h *HttpClientMetric) initMeasures(meter metric.Meter) error {
+	d, err := newHttpClientRequestDurationMeasures(meter)
+	if err != nil {
+		return err
+	}
+	req, err := newHttpBodySizeMeasures(meter, http_client_request_body_size, "Size of HTTP client request bodies.")
+	if err != nil {
+		return err
+	}
+	resp, err := newHttpBodySizeMeasures(meter, http_client_response_body_size, "Size of HTTP client response bodies.")
+	if err != nil {
+		return err
+	}
+	h.clientRequestBodySize, h.clientResponseBodySize = req, resp
+	h.clientRequestDuration = d
+	return nil
+}
+
 func newHttpClientRequestDurationMeasures(meter metric.Meter) (metric.Float64Histogram, error) {
 	mu.Lock()
 	defer mu.Unlock()
@@ -127,8 +187,7 @@ func newHttpClientRequestDurationMeasures(meter metric.Meter) (metric.Float64His
 		return nil, errors.New("nil meter")
 	}
 	d, err := meter.Float64Histogram(http_client_request_duration,
-		metric.WithUnit("ms"),
-		metric.WithDescription("Duration of HTTP client requests."))
+		utils.DurationHistogramOptions("Duration of HTTP client requests.")...)
 	if err == nil {
 		return d, nil
 	} else {
@@ -136,9 +195,58 @@ func newHttpClientRequestDurationMeasures(meter metric.Meter) (metric.Float64His
 	}
 }
 
+func newHttpServerActiveRequestsMeasures(meter metric.Meter) (metric.Int64UpDownCounter, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	if meter == nil {
+		return nil, errors.New("nil meter")
+	}
+	a, err := meter.Int64UpDownCounter(http_server_active_requests,
+		metric.WithUnit("{request}"),
+		metric.WithDescription("Number of active HTTP server requests."))
+	if err != nil {
+		return a, errors.New(fmt.Sprintf("failed to create http.server.active_requests counter, %v", err))
+	}
+	return a, nil
+}
+
+func newHttpBodySizeMeasures(meter metric.Meter, name string, description string) (metric.Int64Histogram, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	if meter == nil {
+		return nil, errors.New("nil meter")
+	}
+	b, err := meter.Int64Histogram(name, metric.WithUnit("By"), metric.WithDescription(description))
+	if err != nil {
+		return b, errors.New(fmt.Sprintf("failed to create %s histogram, %v", name, err))
+	}
+	return b, nil
+}
+
 type httpMetricContext struct {
-	startTime       time.Time
-	startAttributes []attribute.KeyValue
+	startTime        time.Time
+	startAttributes  []attribute.KeyValue
+	activeAttributes attribute.Set
+}
+
+// bodySize looks up the body size reported by the attrs extractor, it is
+// not a metric attribute itself.
+func bodySize(attrs []attribute.KeyValue, key attribute.Key) (int64, bool) {
+	for _, attr := range attrs {
+		if attr.Key == key {
+			return attr.Value.AsInt64(), true
+		}
+	}
+	return 0, false
+}
+
+func recordBodySizes(ctx context.Context, attrs []attribute.KeyValue, request, response metric.Int64Histogram, set attribute.Set) {
+	if size, ok := bodySize(attrs, semconv.HTTPRequestBodySizeKey); ok && request != nil {
+		request.Record(ctx, size, metric.WithAttributeSet(set))
+	}
+	if size, ok := bodySize(attrs, semconv.HTTPResponseBodySizeKey); ok && response != nil {
+		response.Record(ctx, size, metric.WithAttributeSet(set))
+	}
 }
 
 func (h *HttpServerMetric) OnBeforeStart(parentContext context.Context, startTime time.Time) context.Context {
@@ -146,12 +254,29 @@ func (h *HttpServerMetric) OnBeforeStart(parentContext context.Context, startTim
 }
 
 func (h *HttpServerMetric) OnBeforeEnd(ctx context.Context, startAttributes []attribute.KeyValue, startTime time.Time) context.Context {
+	h.lazyInit()
+	// shadow a copy, the start attributes are still to be set on the span
+	n, activeAttrs := utils.Shadow(append([]attribute.KeyValue(nil), startAttributes...), httpActiveRequestsConv)
+	activeSet := attribute.NewSet(activeAttrs[0:n]...)
+	if h.serverActiveRequests != nil {
+		h.serverActiveRequests.Add(ctx, 1, metric.WithAttributeSet(activeSet))
+	}
 	return context.WithValue(ctx, h.key, httpMetricContext{
-		startTime:       startTime,
-		startAttributes: startAttributes,
+		startTime:        startTime,
+		startAttributes:  startAttributes,
+		activeAttributes: activeSet,
 	})
 }
 
+func (h *HttpServerMetric) lazyInit() {
+	if h.serverRequestDuration != nil {
+		return
+	}
+	if err := h.initMeasures(globalMeter); err != nil {
+		log.Printf("failed to create http server metrics, err is %v\n", err)
+	}
+}
+
 func (h *HttpServerMetric) OnAfterStart(context context.Context, endTime time.Time) {
 	return
 }
@@ -160,50 +285,49 @@ func (h *HttpServerMetric) OnAfterEnd(context context.Context, endAttributes []a
 	mc := context.Value(h.key).(httpMetricContext)
 	startTime, startAttributes := mc.startTime, mc.startAttributes
 	// end attributes should be shadowed by AttrsShadower
-	if h.serverRequestDuration == nil {
-		var err error
-		h.serverRequestDuration, err = newHttpServerRequestDurationMeasures(globalMeter)
-		if err != nil {
-			log.Printf("failed to create serverRequestDuration, err is %v\n", err)
-		}
+	h.lazyInit()
+	if h.serverActiveRequests != nil {
+		h.serverActiveRequests.Add(context, -1, metric.WithAttributeSet(mc.activeAttributes))
 	}
 	endAttributes = append(endAttributes, startAttributes...)
 	n, metricsAttrs := utils.Shadow(endAttributes, httpMetricsConv)
 	if h.serverRequestDuration != nil {
-		h.serverRequestDuration.Record(context, float64(endTime.Sub(startTime).Milliseconds()), metric.WithAttributeSet(attribute.NewSet(metricsAttrs[0:n]...)))
+		set := attribute.NewSet(metricsAttrs[0:n]...)
+		h.serverRequestDuration.Record(context, utils.DurationValue(endTime.Sub(startTime)), metric.WithAttributeSet(set))
+		recordBodySizes(context, metricsAttrs[n:], h.serverRequestBodySize, h.serverResponseBodySize, set)
 	}
 }
 
-func (h HttpClientMetric) OnBeforeStart(parentContext context.Context, startTime time.Time) context.Context {
+func (h *HttpClientMetric) OnBeforeStart(parentContext context.Context, startTime time.Time) context.Context {
 	return parentContext
 }
 
-func (h HttpClientMetric) OnBeforeEnd(ctx context.Context, startAttributes []attribute.KeyValue, startTime time.Time) context.Context {
+func (h *HttpClientMetric) OnBeforeEnd(ctx context.Context, startAttributes []attribute.KeyValue, startTime time.Time) context.Context {
 	return context.WithValue(ctx, h.key, httpMetricContext{
 		startTime:       startTime,
 		startAttributes: startAttributes,
 	})
 }
 
-func (h HttpClientMetric) OnAfterStart(context context.Context, endTime time.Time) {
+func (h *HttpClientMetric) OnAfterStart(context context.Context, endTime time.Time) {
 	return
 }
 
-func (h HttpClientMetric) OnAfterEnd(context context.Context, endAttributes []attribute.KeyValue, endTime time.Time) {
+func (h *HttpClientMetric) OnAfterEnd(context context.Context, endAttributes []attribute.KeyValue, endTime time.Time) {
 	mc := context.Value(h.key).(httpMetricContext)
 	startTime, startAttributes := mc.startTime, mc.startAttributes
 	// end attributes should be shadowed by AttrsShadower
 	if h.clientRequestDuration == nil {
-		var err error
 		// second change to init the metric
-		h.clientRequestDuration, err = newHttpClientRequestDurationMeasures(globalMeter)
-		if err != nil {
-			log.Printf("failed to create clientRequestDuration, err is %v\n", err)
+		if err := h.initMeasures(globalMeter); err != nil {
+			log.Printf("failed to create http client metrics, err is %v\n", err)
 		}
 	}
 	endAttributes = append(endAttributes, startAttributes...)
 	n, metricsAttrs := utils.Shadow(endAttributes, httpMetricsConv)
 	if h.clientRequestDuration != nil {
-		h.clientRequestDuration.Record(context, float64(endTime.Sub(startTime).Milliseconds()), metric.WithAttributeSet(attribute.NewSet(metricsAttrs[0:n]...)))
+		set := attribute.NewSet(metricsAttrs[0:n]...)
+		h.clientRequestDuration.Record(context, utils.DurationValue(endTime.Sub(startTime)), metric.WithAttributeSet(set))
+		recordBodySizes(context, metricsAttrs[n:], h.clientRequestBodySize, h.clientResponseBodySize, set)
 	}
 }
@@ -225,3 +225,72 @@ func TestServerNilMeter(t *testing.T) {
 		panic(err)
 	}
 }
+
+func TestHttpServerActiveRequestsAndBodySize(t *testing.T) {
+	reader := metric.NewManualReader()
+	mp := metric.NewMeterProvider(metric.WithReader(reader))
+	server, err := newHttpServerMetric("test", mp.Meter("test-meter"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	startAttrs := []attribute.KeyValue{semconv.HTTPRequestMethodKey.String("GET"), semconv.URLPath("/users")}
+	ctx := context.Background()
+	start := time.Now()
+	ctx = server.OnBeforeStart(ctx, start)
+	ctx = server.OnBeforeEnd(ctx, startAttrs, start)
+	rm := &metricdata.ResourceMetrics{}
+	reader.Collect(ctx, rm)
+	active := rm.ScopeMetrics[0].Metrics[0]
+	if active.Name != "http.server.active_requests" || active.Data.(metricdata.Sum[int64]).DataPoints[0].Value != 1 {
+		t.Fatalf("expected one active request, got %+v", active)
+	}
+	if _, ok := active.Data.(metricdata.Sum[int64]).DataPoints[0].Attributes.Value(semconv.URLPathKey); ok {
+		t.Fatal("url.path should not be an active requests attribute")
+	}
+	server.OnAfterStart(ctx, start)
+	server.OnAfterEnd(ctx, []attribute.KeyValue{semconv.HTTPResponseStatusCode(200), semconv.HTTPRequestBodySize(10), semconv.HTTPResponseBodySize(20)}, time.Now())
+	rm = &metricdata.ResourceMetrics{}
+	reader.Collect(ctx, rm)
+	sums := map[string]int64{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		switch data := m.Data.(type) {
+		case metricdata.Sum[int64]:
+			sums[m.Name] = data.DataPoints[0].Value
+		case metricdata.Histogram[int64]:
+			sums[m.Name] = data.DataPoints[0].Sum
+			if _, ok := data.DataPoints[0].Attributes.Value(semconv.HTTPResponseStatusCodeKey); !ok {
+				t.Fatalf("expected %s to carry the status code", m.Name)
+			}
+		}
+	}
+	if sums["http.server.active_requests"] != 0 || sums["http.server.request.body.size"] != 10 || sums["http.server.response.body.size"] != 20 {
+		t.Fatalf("unexpected metrics %v", sums)
+	}
+}
+
+func TestHttpClientBodySize(t *testing.T) {
+	reader := metric.NewManualReader()
+	mp := metric.NewMeterProvider(metric.WithReader(reader))
+	client, err := newHttpClientMetric("test", mp.Meter("test-meter"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	start := time.Now()
+	ctx = client.OnBeforeStart(ctx, start)
+	ctx = client.OnBeforeEnd(ctx, []attribute.KeyValue{}, start)
+	client.OnAfterStart(ctx, start)
+	client.OnAfterEnd(ctx, []attribute.KeyValue{semconv.HTTPResponseBodySize(42)}, time.Now())
+	rm := &metricdata.ResourceMetrics{}
+	reader.Collect(ctx, rm)
+	names := make([]string, 0)
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		names = append(names, m.Name)
+		if m.Name == "http.client.response.body.size" && m.Data.(metricdata.Histogram[int64]).DataPoints[0].Sum != 42 {
+			t.Fatalf("unexpected response body size %+v", m.Data)
+		}
+	}
+	if len(names) != 2 || names[1] != "http.client.response.body.size" {
+		t.Fatalf("expected the request body size to be skipped when unknown, got %v", names)
+	}
+}
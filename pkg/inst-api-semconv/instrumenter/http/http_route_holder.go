@@ -0,0 +1,101 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"sync"
+
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/utils"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// httpRouteState is shared by the server span and the framework rules, the
+// route is usually resolved by the router after the server span is started.
+type httpRouteState struct {
+	mu     sync.Mutex
+	span   trace.Span
+	method string
+	route  string
+}
+
+// HttpRouteHolder is a ContextCustomizer of the http server instrumenters, it
+// keeps the route state of the server span in the context so that the
+// framework rules can refine http.route and the span name via
+// UpdateHttpRoute instead of starting a nested server span.
+type HttpRouteHolder[REQUEST any, RESPONSE any] struct {
+	Getter HttpServerAttrsGetter[REQUEST, RESPONSE]
+}
+
+func (h *HttpRouteHolder[REQUEST, RESPONSE]) OnStart(ctx context.Context, request REQUEST, startAttributes []attribute.KeyValue) context.Context {
+	state := &httpRouteState{span: trace.SpanFromContext(ctx), method: h.Getter.GetRequestMethod(request)}
+	// the route may be resolved before the span is started, e.g. hertz
+	// starts the server span when the request is finished
+	if pending, ok := ctx.Value(utils.OTEL_CONTEXT_KEY).(*httpRouteState); ok && pending.span == nil && pending.route != "" {
+		state.update(pending.route)
+	}
+	return context.WithValue(ctx, utils.OTEL_CONTEXT_KEY, state)
+}
+
+func (s *httpRouteState) update(route string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.route = route
+	if s.span == nil {
+		return
+	}
+	if s.method == "" {
+		s.span.SetName("HTTP")
+	} else {
+		s.span.SetName(s.method + " " + route)
+	}
+	s.span.SetAttributes(semconv.HTTPRoute(route))
+}
+
+// UpdateHttpRoute sets http.route of the server span in ctx and renames the
+// span to "{method} {route}", it returns false when ctx does not belong to a
+// server span started with a HttpRouteHolder.
+func UpdateHttpRoute(ctx context.Context, route string) bool {
+	if ctx == nil {
+		return false
+	}
+	state, ok := ctx.Value(utils.OTEL_CONTEXT_KEY).(*httpRouteState)
+	if !ok || state.span == nil {
+		return false
+	}
+	if route != "" {
+		state.update(route)
+	}
+	return true
+}
+
+// ContextWithHttpRoute carries a route resolved before the server span is
+// started, it is applied when the span is started with a HttpRouteHolder.
+func ContextWithHttpRoute(ctx context.Context, route string) context.Context {
+	return context.WithValue(ctx, utils.OTEL_CONTEXT_KEY, &httpRouteState{route: route})
+}
+
+// getHttpRoute returns the route refined by the framework rules.
+func getHttpRoute(ctx context.Context) (string, bool) {
+	state, ok := ctx.Value(utils.OTEL_CONTEXT_KEY).(*httpRouteState)
+	if !ok {
+		return "", false
+	}
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	return state.route, state.route != ""
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func startRouteHolderSpan(ctx context.Context) (context.Context, *tracetest.SpanRecorder) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	ctx, _ = tp.Tracer("test").Start(ctx, "GET")
+	holder := &HttpRouteHolder[testRequest, testResponse]{Getter: httpServerAttrsGetter{}}
+	return holder.OnStart(ctx, testRequest{}, nil), sr
+}
+
+func TestUpdateHttpRoute(t *testing.T) {
+	ctx, sr := startRouteHolderSpan(context.Background())
+	if !UpdateHttpRoute(ctx, "/users/:id") {
+		t.Fatal("expected the route to be updated")
+	}
+	route, ok := getHttpRoute(ctx)
+	if !ok || route != "/users/:id" {
+		t.Fatalf("unexpected route %q", route)
+	}
+	trace.SpanFromContext(ctx).End()
+	spans := sr.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	if spans[0].Name() != "GET /users/:id" {
+		t.Fatalf("unexpected span name %q", spans[0].Name())
+	}
+	found := false
+	for _, attr := range spans[0].Attributes() {
+		if attr == semconv.HTTPRoute("/users/:id") {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatal("expected http.route attribute")
+	}
+}
+
+func TestContextWithHttpRoute(t *testing.T) {
+	ctx, sr := startRouteHolderSpan(ContextWithHttpRoute(context.Background(), "/orders/:id"))
+	trace.SpanFromContext(ctx).End()
+	if name := sr.Ended()[0].Name(); name != "GET /orders/:id" {
+		t.Fatalf("unexpected span name %q", name)
+	}
+	if route, ok := getHttpRoute(ctx); !ok || route != "/orders/:id" {
+		t.Fatalf("unexpected route %q", route)
+	}
+}
+
+func TestUpdateHttpRouteWithoutHolder(t *testing.T) {
+	if UpdateHttpRoute(context.Background(), "/users/:id") {
+		t.Fatal("expected no route holder in context")
+	}
+	if UpdateHttpRoute(ContextWithHttpRoute(context.Background(), "/users/:id"), "/users/:id") {
+		t.Fatal("expected pending route without span to be rejected")
+	}
+}
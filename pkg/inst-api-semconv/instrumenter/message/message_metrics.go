@@ -0,0 +1,166 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package message
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api-semconv/instrumenter/utils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+)
+
+const messaging_client_operation_duration = "messaging.client.operation.duration"
+
+const messaging_process_duration = "messaging.process.duration"
+
+const messaging_client_sent_messages = "messaging.client.sent.messages"
+
+const messaging_client_consumed_messages = "messaging.client.consumed.messages"
+
+// MessageMetric records the metrics of one kind of operation, the publish
+// and receive operations are client operations while the process operations
+// have a duration of their own.
+type MessageMetric struct {
+	key       attribute.Key
+	operation MessageOperation
+	duration  metric.Float64Histogram
+	messages  metric.Int64Counter
+}
+
+var mu sync.Mutex
+
+var messageMetricsConv = map[attribute.Key]bool{
+	semconv.MessagingSystemKey:                 true,
+	semconv.MessagingOperationNameKey:          true,
+	semconv.MessagingOperationTypeKey:          true,
+	semconv.MessagingDestinationNameKey:        true,
+	semconv.MessagingDestinationPartitionIDKey: true,
+	semconv.MessagingConsumerGroupNameKey:      true,
+	semconv.ServerAddressKey:                   true,
+	semconv.ServerPortKey:                      true,
+	semconv.ErrorTypeKey:                       true,
+}
+
+var globalMeter metric.Meter
+
+// InitMessageMetrics so we need to make sure the otel_setup is executed before all the init() function
+// related to issue https://github.com/alibaba/opentelemetry-go-auto-instrumentation/issues/48
+func InitMessageMetrics(m metric.Meter) {
+	mu.Lock()
+	defer mu.Unlock()
+	globalMeter = m
+}
+
+func MessageMetrics(key string, operation MessageOperation) *MessageMetric {
+	mu.Lock()
+	defer mu.Unlock()
+	return &MessageMetric{key: attribute.Key(key), operation: operation}
+}
+
+// for test only
+func newMessageMetric(key string, operation MessageOperation, meter metric.Meter) (*MessageMetric, error) {
+	m := &MessageMetric{
+		key:       attribute.Key(key),
+		operation: operation,
+	}
+	if err := m.initMeasures(meter); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *MessageMetric) initMeasures(meter metric.Meter) error {
+	mu.Lock()
+	defer mu.Unlock()
+	if meter == nil {
+		return errors.New("nil meter")
+	}
+	durationName, durationDesc := messaging_client_operation_duration, "Duration of messaging operation initiated by a producer or consumer client."
+	messagesName, messagesDesc := messaging_client_consumed_messages, "Number of messages that were delivered to the application."
+	switch m.operation {
+	case PROCESS:
+		durationName, durationDesc = messaging_process_duration, "Duration of processing operation."
+	case PUBLISH:
+		messagesName, messagesDesc = messaging_client_sent_messages, "Number of messages producer attempted to send to the broker."
+	}
+	d, err := meter.Float64Histogram(durationName, utils.DurationHistogramOptions(durationDesc)...)
+	if err != nil {
+		return errors.New(fmt.Sprintf("failed to create %s histogram, %v", durationName, err))
+	}
+	c, err := meter.Int64Counter(messagesName, metric.WithUnit("{message}"), metric.WithDescription(messagesDesc))
+	if err != nil {
+		return errors.New(fmt.Sprintf("failed to create %s counter, %v", messagesName, err))
+	}
+	m.duration, m.messages = d, c
+	return nil
+}
+
+type messageMetricContext struct {
+	startTime       time.Time
+	startAttributes []attribute.KeyValue
+}
+
+// messageCount counts a single message unless a batch is reported.
+func messageCount(attrs []attribute.KeyValue) int64 {
+	for _, attr := range attrs {
+		if attr.Key == semconv.MessagingBatchMessageCountKey && attr.Value.AsInt64() > 0 {
+			return attr.Value.AsInt64()
+		}
+	}
+	return 1
+}
+
+func (m *MessageMetric) OnBeforeStart(parentContext context.Context, startTime time.Time) context.Context {
+	return parentContext
+}
+
+func (m *MessageMetric) OnBeforeEnd(ctx context.Context, startAttributes []attribute.KeyValue, startTime time.Time) context.Context {
+	return context.WithValue(ctx, m.key, messageMetricContext{
+		startTime:       startTime,
+		startAttributes: startAttributes,
+	})
+}
+
+func (m *MessageMetric) OnAfterStart(context context.Context, endTime time.Time) {
+	return
+}
+
+func (m *MessageMetric) OnAfterEnd(context context.Context, endAttributes []attribute.KeyValue, endTime time.Time) {
+	mc, ok := context.Value(m.key).(messageMetricContext)
+	if !ok {
+		return
+	}
+	startTime, startAttributes := mc.startTime, mc.startAttributes
+	if m.duration == nil {
+		// second chance to init the metric
+		if err := m.initMeasures(globalMeter); err != nil {
+			log.Printf("failed to create messaging metrics, err is %v\n", err)
+		}
+	}
+	endAttributes = append(endAttributes, startAttributes...)
+	n, metricsAttrs := utils.Shadow(endAttributes, messageMetricsConv)
+	if m.duration != nil {
+		set := metric.WithAttributeSet(attribute.NewSet(metricsAttrs[0:n]...))
+		m.duration.Record(context, utils.DurationValue(endTime.Sub(startTime)), set)
+		m.messages.Add(context, messageCount(metricsAttrs[n:]), set)
+	}
+}
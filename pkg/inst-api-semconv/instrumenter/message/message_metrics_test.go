@@ -0,0 +1,118 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package message
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+)
+
+func collectMessageMetrics(t *testing.T, reader metric.Reader) map[string]metricdata.Metrics {
+	rm := &metricdata.ResourceMetrics{}
+	if err := reader.Collect(context.Background(), rm); err != nil {
+		t.Fatal(err)
+	}
+	metrics := make(map[string]metricdata.Metrics)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			metrics[m.Name] = m
+		}
+	}
+	return metrics
+}
+
+func runMessageOperation(m *MessageMetric, startAttrs, endAttrs []attribute.KeyValue) {
+	ctx := context.Background()
+	start := time.Now()
+	ctx = m.OnBeforeStart(ctx, start)
+	ctx = m.OnBeforeEnd(ctx, startAttrs, start)
+	m.OnAfterStart(ctx, start)
+	m.OnAfterEnd(ctx, endAttrs, time.Now())
+}
+
+func TestMessagePublishMetrics(t *testing.T) {
+	reader := metric.NewManualReader()
+	mp := metric.NewMeterProvider(metric.WithReader(reader))
+	m, err := newMessageMetric("test", PUBLISH, mp.Meter("test-meter"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	runMessageOperation(m, []attribute.KeyValue{
+		semconv.MessagingSystemKafka,
+		semconv.MessagingDestinationName("orders"),
+		semconv.MessagingMessageConversationID("c1"),
+	}, []attribute.KeyValue{semconv.MessagingBatchMessageCount(3)})
+	metrics := collectMessageMetrics(t, reader)
+	duration, ok := metrics["messaging.client.operation.duration"]
+	if !ok {
+		t.Fatalf("expected the client operation duration, got %v", metrics)
+	}
+	attrs := duration.Data.(metricdata.Histogram[float64]).DataPoints[0].Attributes
+	if v, _ := attrs.Value(semconv.MessagingDestinationNameKey); v.AsString() != "orders" {
+		t.Fatalf("expected the destination attribute, got %v", attrs)
+	}
+	if _, ok := attrs.Value(semconv.MessagingMessageConversationIDKey); ok {
+		t.Fatal("the conversation id should not be a metric attribute")
+	}
+	if sent := metrics["messaging.client.sent.messages"].Data.(metricdata.Sum[int64]).DataPoints[0].Value; sent != 3 {
+		t.Fatalf("expected 3 sent messages, got %d", sent)
+	}
+}
+
+func TestMessageConsumerMetrics(t *testing.T) {
+	reader := metric.NewManualReader()
+	mp := metric.NewMeterProvider(metric.WithReader(reader))
+	process, err := newMessageMetric("process", PROCESS, mp.Meter("test-meter"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	receive, err := newMessageMetric("receive", RECEIVE, mp.Meter("test-meter"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	runMessageOperation(process, []attribute.KeyValue{semconv.MessagingSystemKafka}, nil)
+	runMessageOperation(receive, []attribute.KeyValue{semconv.MessagingSystemRabbitmq}, []attribute.KeyValue{semconv.MessagingBatchMessageCount(0)})
+	metrics := collectMessageMetrics(t, reader)
+	if _, ok := metrics["messaging.process.duration"]; !ok {
+		t.Fatalf("expected the process duration, got %v", metrics)
+	}
+	if _, ok := metrics["messaging.client.operation.duration"]; !ok {
+		t.Fatalf("expected the receive duration, got %v", metrics)
+	}
+	consumed := metrics["messaging.client.consumed.messages"].Data.(metricdata.Sum[int64]).DataPoints
+	if len(consumed) != 2 || consumed[0].Value != 1 || consumed[1].Value != 1 {
+		t.Fatalf("expected one message consumed per operation, got %v", consumed)
+	}
+}
+
+func TestLazyMessageMetrics(t *testing.T) {
+	reader := metric.NewManualReader()
+	mp := metric.NewMeterProvider(metric.WithReader(reader))
+	InitMessageMetrics(mp.Meter("test-meter"))
+	defer InitMessageMetrics(nil)
+	runMessageOperation(MessageMetrics("test", PUBLISH), nil, nil)
+	if _, ok := collectMessageMetrics(t, reader)["messaging.client.sent.messages"]; !ok {
+		t.Fatal("expected the metrics to be created from the global meter")
+	}
+	if _, err := newMessageMetric("test", PUBLISH, nil); err == nil {
+		t.Fatal("expected an error for a nil meter")
+	}
+}
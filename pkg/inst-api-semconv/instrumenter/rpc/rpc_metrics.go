@@ -24,6 +24,7 @@ import (
 	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
 	"log"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -31,14 +32,41 @@ const rpc_server_request_duration = "rpc.server.duration"
 
 const rpc_client_request_duration = "rpc.client.duration"
 
+const rpc_server_request_size = "rpc.server.request.size"
+
+const rpc_server_response_size = "rpc.server.response.size"
+
+const rpc_server_requests_per_rpc = "rpc.server.requests_per_rpc"
+
+const rpc_server_responses_per_rpc = "rpc.server.responses_per_rpc"
+
+const rpc_client_request_size = "rpc.client.request.size"
+
+const rpc_client_response_size = "rpc.client.response.size"
+
+const rpc_client_requests_per_rpc = "rpc.client.requests_per_rpc"
+
+const rpc_client_responses_per_rpc = "rpc.client.responses_per_rpc"
+
 type RpcServerMetric struct {
 	key                   attribute.Key
 	serverRequestDuration metric.Float64Histogram
+	serverMessages        lazyMessageMeasures
 }
 
 type RpcClientMetric struct {
 	key                   attribute.Key
 	clientRequestDuration metric.Float64Histogram
+	clientMessages        lazyMessageMeasures
+}
+
+// rpcMessageMeasures are the message metrics of either the client or the
+// server side.
+type rpcMessageMeasures struct {
+	requestSize     metric.Int64Histogram
+	responseSize    metric.Int64Histogram
+	requestsPerRpc  metric.Int64Histogram
+	responsesPerRpc metric.Int64Histogram
 }
 
 var mu sync.Mutex
@@ -79,8 +107,7 @@ func newRpcServerRequestDurationMeasures(meter metric.Meter) (metric.Float64Hist
 		return nil, errors.New("nil meter")
 	}
 	d, err := meter.Float64Histogram(rpc_server_request_duration,
-		metric.WithUnit("ms"),
-		metric.WithDescription("Duration of rpc server requests."))
+		utils.DurationHistogramOptions("Duration of rpc server requests.")...)
 	if err == nil {
 		return d, nil
 	} else {
@@ -95,8 +122,7 @@ func newRpcClientRequestDurationMeasures(meter metric.Meter) (metric.Float64Hist
 		return nil, errors.New("nil meter")
 	}
 	d, err := meter.Float64Histogram(rpc_client_request_duration,
-		metric.WithUnit("ms"),
-		metric.WithDescription("Duration of rpc client requests."))
+		utils.DurationHistogramOptions("Duration of rpc client requests.")...)
 	if err == nil {
 		return d, nil
 	} else {
@@ -104,9 +130,139 @@ func newRpcClientRequestDurationMeasures(meter metric.Meter) (metric.Float64Hist
 	}
 }
 
+func newRpcServerMessageMeasures(meter metric.Meter) (*rpcMessageMeasures, error) {
+	return newRpcMessageMeasures(meter, rpc_server_request_size, rpc_server_response_size, rpc_server_requests_per_rpc, rpc_server_responses_per_rpc)
+}
+
+func newRpcClientMessageMeasures(meter metric.Meter) (*rpcMessageMeasures, error) {
+	return newRpcMessageMeasures(meter, rpc_client_request_size, rpc_client_response_size, rpc_client_requests_per_rpc, rpc_client_responses_per_rpc)
+}
+
+func newRpcMessageMeasures(meter metric.Meter, requestSize, responseSize, requestsPerRpc, responsesPerRpc string) (*rpcMessageMeasures, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	if meter == nil {
+		return nil, errors.New("nil meter")
+	}
+	m := &rpcMessageMeasures{}
+	var err error
+	if m.requestSize, err = meter.Int64Histogram(requestSize,
+		metric.WithUnit("By"),
+		metric.WithDescription("Measures the size of RPC request messages (uncompressed).")); err != nil {
+		return nil, errors.New(fmt.Sprintf("failed to create %s histogram, %v", requestSize, err))
+	}
+	if m.responseSize, err = meter.Int64Histogram(responseSize,
+		metric.WithUnit("By"),
+		metric.WithDescription("Measures the size of RPC response messages (uncompressed).")); err != nil {
+		return nil, errors.New(fmt.Sprintf("failed to create %s histogram, %v", responseSize, err))
+	}
+	if m.requestsPerRpc, err = meter.Int64Histogram(requestsPerRpc,
+		metric.WithUnit("{count}"),
+		metric.WithDescription("Measures the number of messages received per RPC.")); err != nil {
+		return nil, errors.New(fmt.Sprintf("failed to create %s histogram, %v", requestsPerRpc, err))
+	}
+	if m.responsesPerRpc, err = meter.Int64Histogram(responsesPerRpc,
+		metric.WithUnit("{count}"),
+		metric.WithDescription("Measures the number of messages sent per RPC.")); err != nil {
+		return nil, errors.New(fmt.Sprintf("failed to create %s histogram, %v", responsesPerRpc, err))
+	}
+	return m, nil
+}
+
+// lazyMessageMeasures creates the message measures once the meter is set, the
+// rpcs ended before it record no message metrics.
+type lazyMessageMeasures struct {
+	mu       sync.Mutex
+	measures atomic.Pointer[rpcMessageMeasures]
+	logged   bool
+}
+
+func (l *lazyMessageMeasures) get(name string, create func(metric.Meter) (*rpcMessageMeasures, error)) *rpcMessageMeasures {
+	if m := l.measures.Load(); m != nil {
+		return m
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if m := l.measures.Load(); m != nil {
+		return m
+	}
+	mu.Lock()
+	meter := globalMeter
+	mu.Unlock()
+	m, err := create(meter)
+	if err != nil {
+		if !l.logged {
+			l.logged = true
+			log.Printf("failed to create %s, err is %v\n", name, err)
+		}
+		return nil
+	}
+	l.measures.Store(m)
+	return m
+}
+
+type rpcMessageKey struct{}
+
+// rpcMessages counts the messages of one rpc, the sizes are recorded as the
+// messages come so that a long stream does not keep them.
+type rpcMessages struct {
+	measures  *rpcMessageMeasures
+	set       metric.MeasurementOption
+	requests  atomic.Int64
+	responses atomic.Int64
+}
+
+func newRpcMessages(measures *rpcMessageMeasures, startAttributes []attribute.KeyValue) *rpcMessages {
+	attrs := make([]attribute.KeyValue, len(startAttributes))
+	copy(attrs, startAttributes)
+	n, metricsAttrs := utils.Shadow(attrs, rpcMetricsConv)
+	return &rpcMessages{
+		measures: measures,
+		set:      metric.WithAttributeSet(attribute.NewSet(metricsAttrs[0:n]...)),
+	}
+}
+
+// RecordRpcRequestMessage records a request message of an rpc, i.e. a sent
+// message on the client side and a received one on the server side. ctx is
+// the context returned by the instrumenter start.
+func RecordRpcRequestMessage(ctx context.Context, size int64) {
+	if m, ok := ctx.Value(rpcMessageKey{}).(*rpcMessages); ok {
+		m.requests.Add(1)
+		if m.measures != nil {
+			m.measures.requestSize.Record(ctx, size, m.set)
+		}
+	}
+}
+
+// RecordRpcResponseMessage records a response message of an rpc, i.e. a
+// received message on the client side and a sent one on the server side.
+func RecordRpcResponseMessage(ctx context.Context, size int64) {
+	if m, ok := ctx.Value(rpcMessageKey{}).(*rpcMessages); ok {
+		m.responses.Add(1)
+		if m.measures != nil {
+			m.measures.responseSize.Record(ctx, size, m.set)
+		}
+	}
+}
+
+// recordCounts skips the rpcs whose messages are not recorded by the
+// instrumentation at all.
+func (m *rpcMessages) recordCounts(ctx context.Context, set metric.MeasurementOption) {
+	if m == nil || m.measures == nil {
+		return
+	}
+	requests, responses := m.requests.Load(), m.responses.Load()
+	if requests == 0 && responses == 0 {
+		return
+	}
+	m.measures.requestsPerRpc.Record(ctx, requests, set)
+	m.measures.responsesPerRpc.Record(ctx, responses, set)
+}
+
 type rpcMetricContext struct {
 	startTime       time.Time
 	startAttributes []attribute.KeyValue
+	messages        *rpcMessages
 }
 
 func (h *RpcServerMetric) OnBeforeStart(parentContext context.Context, startTime time.Time) context.Context {
@@ -114,9 +270,12 @@ func (h *RpcServerMetric) OnBeforeStart(parentContext context.Context, startTime
 }
 
 func (h *RpcServerMetric) OnBeforeEnd(ctx context.Context, startAttributes []attribute.KeyValue, startTime time.Time) context.Context {
+	messages := newRpcMessages(h.serverMessages.get("serverMessages", newRpcServerMessageMeasures), startAttributes)
+	ctx = context.WithValue(ctx, rpcMessageKey{}, messages)
 	return context.WithValue(ctx, h.key, rpcMetricContext{
 		startTime:       startTime,
 		startAttributes: startAttributes,
+		messages:        messages,
 	})
 }
 
@@ -137,9 +296,11 @@ func (h *RpcServerMetric) OnAfterEnd(context context.Context, endAttributes []at
 	}
 	endAttributes = append(endAttributes, startAttributes...)
 	n, metricsAttrs := utils.Shadow(endAttributes, rpcMetricsConv)
+	set := metric.WithAttributeSet(attribute.NewSet(metricsAttrs[0:n]...))
 	if h.serverRequestDuration != nil {
-		h.serverRequestDuration.Record(context, float64(endTime.Sub(startTime).Milliseconds()), metric.WithAttributeSet(attribute.NewSet(metricsAttrs[0:n]...)))
+		h.serverRequestDuration.Record(context, utils.DurationValue(endTime.Sub(startTime)), set)
 	}
+	mc.messages.recordCounts(context, set)
 }
 
 func (h *RpcClientMetric) OnBeforeStart(parentContext context.Context, startTime time.Time) context.Context {
@@ -147,9 +308,12 @@ func (h *RpcClientMetric) OnBeforeStart(parentContext context.Context, startTime
 }
 
 func (h *RpcClientMetric) OnBeforeEnd(ctx context.Context, startAttributes []attribute.KeyValue, startTime time.Time) context.Context {
+	messages := newRpcMessages(h.clientMessages.get("clientMessages", newRpcClientMessageMeasures), startAttributes)
+	ctx = context.WithValue(ctx, rpcMessageKey{}, messages)
 	return context.WithValue(ctx, h.key, rpcMetricContext{
 		startTime:       startTime,
 		startAttributes: startAttributes,
+		messages:        messages,
 	})
 }
 
@@ -175,9 +339,11 @@ func (h *RpcClientMetric) OnAfterEnd(context context.Context, endAttributes []at
 	endAttributes = append(endAttributes, startAttributes...)
 
 	n, metricsAttrs := utils.Shadow(endAttributes, rpcMetricsConv)
+	set := metric.WithAttributeSet(attribute.NewSet(metricsAttrs[0:n]...))
 	if h.clientRequestDuration != nil {
-		h.clientRequestDuration.Record(context, float64(endTime.Sub(startTime).Milliseconds()), metric.WithAttributeSet(attribute.NewSet(metricsAttrs[0:n]...)))
+		h.clientRequestDuration.Record(context, utils.DurationValue(endTime.Sub(startTime)), set)
 	}
+	mc.messages.recordCounts(context, set)
 }
 
 // for test only
@@ -190,6 +356,11 @@ func newRpcServerMetric(key string, meter metric.Meter) (*RpcServerMetric, error
 		return nil, err
 	}
 	m.serverRequestDuration = d
+	messages, err := newRpcServerMessageMeasures(meter)
+	if err != nil {
+		return nil, err
+	}
+	m.serverMessages.measures.Store(messages)
 	return m, nil
 }
 
@@ -203,5 +374,10 @@ func newRpcClientMetric(key string, meter metric.Meter) (*RpcClientMetric, error
 		return nil, err
 	}
 	m.clientRequestDuration = d
+	messages, err := newRpcClientMessageMeasures(meter)
+	if err != nil {
+		return nil, err
+	}
+	m.clientMessages.measures.Store(messages)
 	return m, nil
 }
@@ -22,6 +22,7 @@ import (
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.opentelemetry.io/otel/sdk/resource"
 	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+	"sync"
 	"testing"
 	"time"
 )
@@ -225,3 +226,93 @@ func TestNilServerMeter(t *testing.T) {
 		panic(err)
 	}
 }
+
+func TestRpcStreamMessageMetrics(t *testing.T) {
+	reader := metric.NewManualReader()
+	mp := metric.NewMeterProvider(metric.WithReader(reader))
+	client, err := newRpcClientMetric("test", mp.Meter("test-meter"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	start := time.Now()
+	attrs := []attribute.KeyValue{semconv.RPCSystemKey.String("grpc"), semconv.RPCMethodKey.String("Chat")}
+	ctx = client.OnBeforeEnd(ctx, attrs, start)
+	for i := 0; i < 3; i++ {
+		RecordRpcRequestMessage(ctx, 10)
+	}
+	RecordRpcResponseMessage(ctx, 100)
+	client.OnAfterEnd(ctx, []attribute.KeyValue{}, time.Now())
+	rm := &metricdata.ResourceMetrics{}
+	if err = reader.Collect(ctx, rm); err != nil {
+		t.Fatal(err)
+	}
+	sums := map[string]int64{}
+	counts := map[string]uint64{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		if hist, ok := m.Data.(metricdata.Histogram[int64]); ok {
+			dp := hist.DataPoints[0]
+			if method, _ := dp.Attributes.Value(semconv.RPCMethodKey); method.AsString() != "Chat" {
+				t.Fatalf("expected the start attributes on %s, got %v", m.Name, dp.Attributes)
+			}
+			sums[m.Name], counts[m.Name] = dp.Sum, dp.Count
+		}
+	}
+	if sums["rpc.client.request.size"] != 30 || counts["rpc.client.request.size"] != 3 {
+		t.Fatalf("unexpected request size %v %v", sums, counts)
+	}
+	if sums["rpc.client.response.size"] != 100 {
+		t.Fatalf("unexpected response size %v", sums)
+	}
+	if sums["rpc.client.requests_per_rpc"] != 3 || sums["rpc.client.responses_per_rpc"] != 1 {
+		t.Fatalf("unexpected message counts %v", sums)
+	}
+}
+
+func TestRpcMessageMetricsWithoutMessages(t *testing.T) {
+	reader := metric.NewManualReader()
+	mp := metric.NewMeterProvider(metric.WithReader(reader))
+	server, err := newRpcServerMetric("test", mp.Meter("test-meter"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	start := time.Now()
+	ctx = server.OnBeforeEnd(ctx, []attribute.KeyValue{}, start)
+	server.OnAfterEnd(ctx, []attribute.KeyValue{}, time.Now())
+	rm := &metricdata.ResourceMetrics{}
+	if err = reader.Collect(ctx, rm); err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		if m.Name != "rpc.server.duration" {
+			t.Fatalf("expected no message metrics without messages, got %s", m.Name)
+		}
+	}
+	// no panic without a recorder
+	RecordRpcRequestMessage(context.Background(), 1)
+}
+
+func TestLazyRpcMessageMeasuresConcurrent(t *testing.T) {
+	defer InitRpcMetrics(nil)
+	InitRpcMetrics(nil)
+	server := RpcServerMetrics("net.rpc.server")
+	// retried once the meter is set
+	if server.serverMessages.get("serverMessages", newRpcServerMessageMeasures) != nil {
+		t.Fatal("expected no measures without a meter")
+	}
+	InitRpcMetrics(metric.NewMeterProvider(metric.WithReader(metric.NewManualReader())).Meter("test-meter"))
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx := server.OnBeforeEnd(context.Background(), []attribute.KeyValue{}, time.Now())
+			RecordRpcRequestMessage(ctx, 1)
+		}()
+	}
+	wg.Wait()
+	if server.serverMessages.measures.Load() == nil {
+		t.Fatal("expected the measures to be created")
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      rpc://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	DurationUnitMilliseconds = "ms"
+	DurationUnitSeconds      = "s"
+)
+
+// boundaries recommended by the semantic conventions for durations in seconds
+var secondsBoundaries = []float64{0.005, 0.01, 0.025, 0.05, 0.075, 0.1, 0.25, 0.5, 0.75, 1, 2.5, 5, 7.5, 10}
+
+var durationInSeconds atomic.Bool
+
+// SetDurationUnit switches the unit of the built-in duration histograms, it
+// takes effect for the instruments created afterwards. Milliseconds are kept
+// as the default for compatibility.
+func SetDurationUnit(unit string) {
+	durationInSeconds.Store(unit == DurationUnitSeconds)
+}
+
+func DurationUnit() string {
+	if durationInSeconds.Load() {
+		return DurationUnitSeconds
+	}
+	return DurationUnitMilliseconds
+}
+
+// DurationValue converts d to the value recorded by the duration histograms.
+func DurationValue(d time.Duration) float64 {
+	if durationInSeconds.Load() {
+		return d.Seconds()
+	}
+	return float64(d.Milliseconds())
+}
+
+// DurationHistogramOptions returns the options of a duration histogram in the
+// configured unit.
+func DurationHistogramOptions(description string) []metric.Float64HistogramOption {
+	opts := []metric.Float64HistogramOption{
+		metric.WithUnit(DurationUnit()),
+		metric.WithDescription(description),
+	}
+	if durationInSeconds.Load() {
+		opts = append(opts, metric.WithExplicitBucketBoundaries(secondsBoundaries...))
+	}
+	return opts
+}
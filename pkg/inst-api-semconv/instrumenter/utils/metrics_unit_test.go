@@ -0,0 +1,37 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDurationUnit(t *testing.T) {
+	defer SetDurationUnit(DurationUnitMilliseconds)
+	if DurationUnit() != DurationUnitMilliseconds || DurationValue(1500*time.Millisecond) != 1500 {
+		t.Fatal("milliseconds should be the default unit")
+	}
+	if len(DurationHistogramOptions("test")) != 2 {
+		t.Fatal("default buckets should be used for milliseconds")
+	}
+	SetDurationUnit(DurationUnitSeconds)
+	if DurationUnit() != DurationUnitSeconds || DurationValue(1500*time.Millisecond) != 1.5 {
+		t.Fatal("expected duration in seconds")
+	}
+	if len(DurationHistogramOptions("test")) != 3 {
+		t.Fatal("seconds buckets should be advised")
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumenter
+
+import (
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+const commonDefaultEnabledEnv = "OTEL_INSTRUMENTATION_COMMON_DEFAULT_ENABLED"
+
+// NamedInstrumentEnabler is the InstrumentEnabler of an instrumentation
+// registered by RegisterInstrumentEnabler, its state can be switched at
+// runtime.
+type NamedInstrumentEnabler struct {
+	name           string
+	defaultEnabled bool
+	envAliases     []string
+	enabled        atomic.Bool
+}
+
+func (e *NamedInstrumentEnabler) Enable() bool {
+	return e.enabled.Load()
+}
+
+func (e *NamedInstrumentEnabler) Name() string {
+	return e.name
+}
+
+// InstrumentEnablerState is the state of a registered instrumentation.
+type InstrumentEnablerState struct {
+	Name           string `json:"name"`
+	Enabled        bool   `json:"enabled"`
+	DefaultEnabled bool   `json:"default_enabled"`
+}
+
+type instrumentEnablerRegistry struct {
+	mu             sync.Mutex
+	enablers       map[string]*NamedInstrumentEnabler
+	defaultEnabled *bool
+	overrides      map[string]bool
+}
+
+var enablerRegistry = &instrumentEnablerRegistry{enablers: make(map[string]*NamedInstrumentEnabler)}
+
+// RegisterInstrumentEnabler returns the enabler of the instrumentation name,
+// the enabler is shared by the rules registering the same name and keeps the
+// defaultEnabled and envAliases of the first registration. The state is
+// resolved in the following order:
+//  1. the override of the configuration file, see ConfigureInstrumentEnablers
+//  2. OTEL_INSTRUMENTATION_<NAME>_ENABLED, then the legacy envAliases in order
+//  3. the default of the configuration file
+//  4. OTEL_INSTRUMENTATION_COMMON_DEFAULT_ENABLED
+//  5. defaultEnabled
+func RegisterInstrumentEnabler(name string, defaultEnabled bool, envAliases ...string) *NamedInstrumentEnabler {
+	r := enablerRegistry
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if e, ok := r.enablers[name]; ok {
+		return e
+	}
+	e := &NamedInstrumentEnabler{name: name, defaultEnabled: defaultEnabled, envAliases: envAliases}
+	e.enabled.Store(r.resolve(e))
+	r.enablers[name] = e
+	return e
+}
+
+// ConfigureInstrumentEnablers applies the instrumentation section of the
+// configuration file, defaultEnabled is ignored when nil. It applies to the
+// registered instrumentations as well as to those registered later.
+func ConfigureInstrumentEnablers(defaultEnabled *bool, overrides map[string]bool) {
+	r := enablerRegistry
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.defaultEnabled = defaultEnabled
+	r.overrides = overrides
+	for _, e := range r.enablers {
+		e.enabled.Store(r.resolve(e))
+	}
+}
+
+// SetInstrumentEnabled switches a registered instrumentation, it returns false
+// when no instrumentation is registered as name.
+func SetInstrumentEnabled(name string, enabled bool) bool {
+	r := enablerRegistry
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e, ok := r.enablers[name]
+	if !ok {
+		return false
+	}
+	e.enabled.Store(enabled)
+	return true
+}
+
+// InstrumentEnablerStates returns the states of all registered
+// instrumentations sorted by name.
+func InstrumentEnablerStates() []InstrumentEnablerState {
+	r := enablerRegistry
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	states := make([]InstrumentEnablerState, 0, len(r.enablers))
+	for _, e := range r.enablers {
+		states = append(states, InstrumentEnablerState{
+			Name:           e.name,
+			Enabled:        e.enabled.Load(),
+			DefaultEnabled: e.defaultEnabled,
+		})
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Name < states[j].Name
+	})
+	return states
+}
+
+func (r *instrumentEnablerRegistry) resolve(e *NamedInstrumentEnabler) bool {
+	if enabled, ok := r.overrides[e.name]; ok {
+		return enabled
+	}
+	if enabled, ok := boolFromEnv(instrumentEnabledEnv(e.name)); ok {
+		return enabled
+	}
+	for _, alias := range e.envAliases {
+		if enabled, ok := boolFromEnv(alias); ok {
+			return enabled
+		}
+	}
+	if r.defaultEnabled != nil {
+		return *r.defaultEnabled
+	}
+	if enabled, ok := boolFromEnv(commonDefaultEnabledEnv); ok {
+		return enabled
+	}
+	return e.defaultEnabled
+}
+
+// instrumentEnabledEnv maps the name to OTEL_INSTRUMENTATION_<NAME>_ENABLED,
+// e.g. "segmentio-kafka" is switched by
+// OTEL_INSTRUMENTATION_SEGMENTIO_KAFKA_ENABLED.
+func instrumentEnabledEnv(name string) string {
+	name = strings.ToUpper(strings.NewReplacer("-", "_", ".", "_", "/", "_").Replace(name))
+	return "OTEL_INSTRUMENTATION_" + name + "_ENABLED"
+}
+
+func boolFromEnv(key string) (bool, bool) {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return false, false
+	}
+	enabled, err := strconv.ParseBool(strings.TrimSpace(v))
+	if err != nil {
+		return false, false
+	}
+	return enabled, true
+}
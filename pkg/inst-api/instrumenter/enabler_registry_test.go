@@ -0,0 +1,108 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumenter
+
+import (
+	"testing"
+)
+
+func TestRegisterInstrumentEnabler(t *testing.T) {
+	t.Setenv("OTEL_INSTRUMENTATION_TEST_DISABLED_ENABLED", "false")
+	if !RegisterInstrumentEnabler("test-default-on", true).Enable() {
+		t.Fatal("expected enabled by default")
+	}
+	if RegisterInstrumentEnabler("test-default-off", false).Enable() {
+		t.Fatal("expected disabled by default")
+	}
+	if RegisterInstrumentEnabler("test.disabled", true).Enable() {
+		t.Fatal("expected disabled by env")
+	}
+	if RegisterInstrumentEnabler("test-default-on", false) != RegisterInstrumentEnabler("test-default-on", true) {
+		t.Fatal("expected the enabler to be shared")
+	}
+}
+
+func TestCommonDefaultEnabled(t *testing.T) {
+	t.Setenv(commonDefaultEnabledEnv, "false")
+	t.Setenv("OTEL_INSTRUMENTATION_TEST_COMMON_ON_ENABLED", "true")
+	if RegisterInstrumentEnabler("test-common-off", true).Enable() {
+		t.Fatal("expected disabled by the common default")
+	}
+	if !RegisterInstrumentEnabler("test-common-on", true).Enable() {
+		t.Fatal("expected enabled by env")
+	}
+}
+
+func TestInstrumentEnablerEnvAlias(t *testing.T) {
+	t.Setenv(commonDefaultEnabledEnv, "true")
+	t.Setenv("OTEL_TEST_ALIAS_ENABLED", "false")
+	if RegisterInstrumentEnabler("test-alias", true, "OTEL_TEST_ALIAS_ENABLED").Enable() {
+		t.Fatal("expected the alias to take precedence over the common default")
+	}
+	t.Setenv("OTEL_TEST_ALIAS_ON_ENABLED", "false")
+	t.Setenv("OTEL_INSTRUMENTATION_TEST_ALIAS_ON_ENABLED", "true")
+	if !RegisterInstrumentEnabler("test-alias-on", true, "OTEL_TEST_ALIAS_ON_ENABLED").Enable() {
+		t.Fatal("expected the env of the instrumentation to take precedence over the alias")
+	}
+	t.Setenv(commonDefaultEnabledEnv, "false")
+	if RegisterInstrumentEnabler("test-alias-unset", true, "OTEL_TEST_ALIAS_UNSET_ENABLED").Enable() {
+		t.Fatal("expected the common default when the alias is not set")
+	}
+}
+
+func TestConfigureInstrumentEnablers(t *testing.T) {
+	defer ConfigureInstrumentEnablers(nil, nil)
+	t.Setenv("OTEL_INSTRUMENTATION_TEST_CONFIG_ENV_ENABLED", "true")
+	e := RegisterInstrumentEnabler("test-config", true)
+	env := RegisterInstrumentEnabler("test-config-env", true)
+	disabled := false
+	ConfigureInstrumentEnablers(&disabled, map[string]bool{"test-config-later": true})
+	if e.Enable() {
+		t.Fatal("expected disabled by the default of the configuration")
+	}
+	if !env.Enable() {
+		t.Fatal("expected the env to take precedence over the default of the configuration")
+	}
+	if !RegisterInstrumentEnabler("test-config-later", false).Enable() {
+		t.Fatal("expected enabled by the override of the configuration")
+	}
+	ConfigureInstrumentEnablers(nil, map[string]bool{"test-config-env": false})
+	if !e.Enable() || env.Enable() {
+		t.Fatal("expected the configuration to be reapplied")
+	}
+}
+
+func TestInstrumentEnablerStates(t *testing.T) {
+	RegisterInstrumentEnabler("test-states", false)
+	if !SetInstrumentEnabled("test-states", true) {
+		t.Fatal("expected the instrumentation to be registered")
+	}
+	if SetInstrumentEnabled("test-states-unknown", true) {
+		t.Fatal("expected unknown instrumentation")
+	}
+	states := InstrumentEnablerStates()
+	found := false
+	for i, state := range states {
+		if i > 0 && states[i-1].Name >= state.Name {
+			t.Fatal("expected sorted states")
+		}
+		if state.Name == "test-states" {
+			found = state.Enabled && !state.DefaultEnabled
+		}
+	}
+	if !found {
+		t.Fatalf("unexpected states %v", states)
+	}
+}
@@ -16,8 +16,10 @@ package instrumenter
 
 import (
 	"context"
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -38,6 +40,12 @@ type SpanStatusExtractor[REQUEST any, RESPONSE any] interface {
 	Extract(span trace.Span, request REQUEST, response RESPONSE, err error)
 }
 
+// SpanLinksExtractor extracts the links of the span, e.g. the contexts of all
+// the messages of a consumed batch.
+type SpanLinksExtractor[REQUEST any] interface {
+	Extract(parentContext context.Context, request REQUEST) []trace.Link
+}
+
 type SpanKeyProvider interface {
 	GetSpanKey() attribute.Key
 }
@@ -77,6 +85,33 @@ func (a *AlwaysConsumerExtractor[any]) Extract(request any) trace.SpanKind {
 	return trace.SpanKindConsumer
 }
 
+// PropagatorSpanLinksExtractor links the span to the span context propagated
+// by each carrier, e.g. the messages of a batch. The carriers without a valid
+// span context and the one the span is parented to are skipped, so a span
+// started from the context of its only message has no link.
+type PropagatorSpanLinksExtractor[REQUEST any] struct {
+	CarriersGetter func(REQUEST) []propagation.TextMapCarrier
+	// Propagator falls back to the global propagator when it is nil
+	Propagator propagation.TextMapPropagator
+}
+
+func (p *PropagatorSpanLinksExtractor[REQUEST]) Extract(parentContext context.Context, request REQUEST) []trace.Link {
+	prop := p.Propagator
+	if prop == nil {
+		prop = otel.GetTextMapPropagator()
+	}
+	parent := trace.SpanContextFromContext(parentContext)
+	carriers := p.CarriersGetter(request)
+	links := make([]trace.Link, 0, len(carriers))
+	for _, carrier := range carriers {
+		sc := trace.SpanContextFromContext(prop.Extract(context.Background(), carrier))
+		if sc.IsValid() && !sc.Equal(parent) {
+			links = append(links, trace.Link{SpanContext: sc})
+		}
+	}
+	return links
+}
+
 type defaultSpanStatusExtractor[REQUEST any, RESPONSE any] struct {
 }
 
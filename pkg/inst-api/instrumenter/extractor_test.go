@@ -15,10 +15,12 @@
 package instrumenter
 
 import (
+	"context"
 	"errors"
 	"testing"
 
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 	"go.opentelemetry.io/otel/trace/noop"
 )
@@ -81,3 +83,37 @@ func TestAlwaysProducerExtractor(t *testing.T) {
 		t.Fatal("expected producer kind")
 	}
 }
+
+func TestPropagatorSpanLinksExtractor(t *testing.T) {
+	traceId, _ := trace.TraceIDFromHex("0102030405060708090a0b0c0d0e0f10")
+	spanId, _ := trace.SpanIDFromHex("0102030405060708")
+	parentSpanId, _ := trace.SpanIDFromHex("0807060504030201")
+	extractor := &PropagatorSpanLinksExtractor[[]map[string]string]{
+		CarriersGetter: func(headers []map[string]string) []propagation.TextMapCarrier {
+			carriers := make([]propagation.TextMapCarrier, 0, len(headers))
+			for _, h := range headers {
+				carriers = append(carriers, propagation.MapCarrier(h))
+			}
+			return carriers
+		},
+		Propagator: propagation.TraceContext{},
+	}
+	headers := []map[string]string{
+		{"traceparent": "00-" + traceId.String() + "-" + parentSpanId.String() + "-01"},
+		{"traceparent": "00-" + traceId.String() + "-" + spanId.String() + "-01"},
+		{},
+	}
+	// the span is parented to the first message
+	parentContext := propagation.TraceContext{}.Extract(context.Background(), propagation.MapCarrier(headers[0]))
+	links := extractor.Extract(parentContext, headers)
+	if len(links) != 1 {
+		t.Fatalf("expected 1 link, got %d", len(links))
+	}
+	if links[0].SpanContext.TraceID() != traceId || links[0].SpanContext.SpanID() != spanId {
+		t.Fatal("unexpected link span context")
+	}
+	// both messages are linked to a span parented elsewhere
+	if links = extractor.Extract(context.Background(), headers); len(links) != 2 {
+		t.Fatalf("expected 2 links, got %d", len(links))
+	}
+}
@@ -38,12 +38,15 @@ type InternalInstrumenter[REQUEST any, RESPONSE any] struct {
 	spanNameExtractor    SpanNameExtractor[REQUEST]
 	spanKindExtractor    SpanKindExtractor[REQUEST]
 	spanStatusExtractor  SpanStatusExtractor[REQUEST, RESPONSE]
+	spanLinksExtractor   SpanLinksExtractor[REQUEST]
 	attributesExtractors []AttributesExtractor[REQUEST, RESPONSE]
 	operationListeners   []OperationListener
 	contextCustomizers   []ContextCustomizer[REQUEST]
 	spanSuppressor       SpanSuppressor
-	tracer               trace.Tracer
-	instVersion          string
+	// suppressedSpanRecorder is nil unless the suppressed spans are recorded
+	suppressedSpanRecorder SuppressedSpanRecorder
+	tracer                 trace.Tracer
+	instVersion            string
 }
 
 type PropagatingToDownstreamInstrumenter[REQUEST any, RESPONSE any] struct {
@@ -61,8 +64,9 @@ type PropagatingFromUpstreamInstrumenter[REQUEST any, RESPONSE any] struct {
 func (i *InternalInstrumenter[REQUEST, RESPONSE]) ShouldStart(parentContext context.Context, request REQUEST) bool {
 	spanKind := i.spanKindExtractor.Extract(request)
 	suppressed := i.spanSuppressor.ShouldSuppress(parentContext, spanKind)
-	// TODO: record suppressed span
-	return !suppressed
+	// the suppressed span is recorded by Start and End when a recorder is
+	// configured, so it should still be started
+	return !suppressed || i.suppressedSpanRecorder != nil
 }
 
 var cachePool = &sync.Pool{
@@ -104,8 +108,21 @@ func (i *InternalInstrumenter[REQUEST, RESPONSE]) doStart(parentContext context.
 	// extract span name
 	spanName := i.spanNameExtractor.Extract(request)
 	spanKind := i.spanKindExtractor.Extract(request)
+	if i.suppressedSpanRecorder != nil && i.spanSuppressor.ShouldSuppress(parentContext, spanKind) {
+		return i.doStartSuppressed(parentContext, request, spanName, spanKind, timestamp)
+	}
 	options = append(options, trace.WithSpanKind(spanKind), trace.WithTimestamp(timestamp))
+	if i.spanLinksExtractor != nil {
+		if links := i.spanLinksExtractor.Extract(parentContext, request); len(links) > 0 {
+			options = append(options, trace.WithLinks(links...))
+		}
+	}
 	newCtx, span := i.tracer.Start(parentContext, spanName, options...)
+	if suppressedSpanFromContext(parentContext) != nil {
+		// the span is nested in a suppressed one, it must not be ended as the
+		// suppressed one
+		newCtx = context.WithValue(newCtx, suppressedSpanKey{}, (*SuppressedSpan)(nil))
+	}
 	attrs := make([]attribute.KeyValue, 0, 20)
 	// extract span attrs
 	for _, extractor := range i.attributesExtractors {
@@ -119,9 +136,33 @@ func (i *InternalInstrumenter[REQUEST, RESPONSE]) doStart(parentContext context.
 		newCtx = listener.OnBeforeEnd(newCtx, attrs, timestamp)
 	}
 	span.SetAttributes(attrs...)
+	newCtx = setPprofLabels(parentContext, newCtx, spanKind, span)
 	return i.spanSuppressor.StoreInContext(newCtx, spanKind, span)
 }
 
+// doStartSuppressed runs the extractors and listeners without creating a
+// span, the parent span stays current so nested operations are parented to
+// it.
+func (i *InternalInstrumenter[REQUEST, RESPONSE]) doStartSuppressed(parentContext context.Context, request REQUEST, spanName string, spanKind trace.SpanKind, timestamp time.Time) context.Context {
+	attrs := make([]attribute.KeyValue, 0, 20)
+	newCtx := parentContext
+	for _, extractor := range i.attributesExtractors {
+		attrs, newCtx = extractor.OnStart(attrs, newCtx, request)
+	}
+	for _, customizer := range i.contextCustomizers {
+		newCtx = customizer.OnStart(newCtx, request, attrs)
+	}
+	for _, listener := range i.operationListeners {
+		newCtx = listener.OnBeforeEnd(newCtx, attrs, timestamp)
+	}
+	return context.WithValue(newCtx, suppressedSpanKey{}, &SuppressedSpan{
+		Name:       spanName,
+		Kind:       spanKind,
+		StartTime:  timestamp,
+		Attributes: attrs,
+	})
+}
+
 func (i *InternalInstrumenter[REQUEST, RESPONSE]) End(ctx context.Context, request REQUEST, response RESPONSE, err error, options ...trace.SpanEndOption) {
 	i.doEnd(ctx, request, response, err, time.Now(), options...)
 }
@@ -133,6 +174,10 @@ func (i *InternalInstrumenter[REQUEST, RESPONSE]) doEnd(ctx context.Context, req
 	for _, listener := range i.operationListeners {
 		listener.OnAfterStart(ctx, timestamp)
 	}
+	if suppressed := suppressedSpanFromContext(ctx); suppressed != nil {
+		i.doEndSuppressed(ctx, suppressed, request, response, err, timestamp)
+		return
+	}
 	span := trace.SpanFromContext(ctx)
 	if err != nil {
 		span.RecordError(err)
@@ -146,8 +191,25 @@ func (i *InternalInstrumenter[REQUEST, RESPONSE]) doEnd(ctx context.Context, req
 	}
 	i.spanStatusExtractor.Extract(span, request, response, err)
 	span.SetAttributes(attrs...)
+	attachProfileIds(span, timestamp)
 	options = append(options, trace.WithTimestamp(timestamp))
 	span.End(options...)
+	restorePprofLabels(ctx, span)
+	for _, listener := range i.operationListeners {
+		listener.OnAfterEnd(ctx, attrs, timestamp)
+	}
+}
+
+func (i *InternalInstrumenter[REQUEST, RESPONSE]) doEndSuppressed(ctx context.Context, suppressed *SuppressedSpan, request REQUEST, response RESPONSE, err error, timestamp time.Time) {
+	attrs := GetCachedAttrs()
+	defer PutCachedAttrs(attrs)
+	for _, extractor := range i.attributesExtractors {
+		attrs, ctx = extractor.OnEnd(attrs, ctx, request, response, err)
+	}
+	suppressed.EndTime = timestamp
+	suppressed.Err = err
+	suppressed.Attributes = append(suppressed.Attributes, attrs...)
+	i.suppressedSpanRecorder.Record(trace.SpanFromContext(ctx), suppressed)
 	for _, listener := range i.operationListeners {
 		listener.OnAfterEnd(ctx, attrs, timestamp)
 	}
@@ -22,8 +22,6 @@ import (
 	"go.opentelemetry.io/otel/trace"
 )
 
-// TODO: add route updater here, now we do not support such controller layer to update route.
-
 type InstrumentEnabler interface {
 	Enable() bool
 }
@@ -44,6 +42,7 @@ type Builder[REQUEST any, RESPONSE any] struct {
 	SpanNameExtractor    SpanNameExtractor[REQUEST]
 	SpanKindExtractor    SpanKindExtractor[REQUEST]
 	SpanStatusExtractor  SpanStatusExtractor[REQUEST, RESPONSE]
+	SpanLinksExtractor   SpanLinksExtractor[REQUEST]
 	AttributesExtractors []AttributesExtractor[REQUEST, RESPONSE]
 	OperationListeners   []OperationListener
 	ContextCustomizers   []ContextCustomizer[REQUEST]
@@ -85,6 +84,11 @@ func (b *Builder[REQUEST, RESPONSE]) SetSpanKindExtractor(spanKindExtractor Span
 	return b
 }
 
+func (b *Builder[REQUEST, RESPONSE]) SetSpanLinksExtractor(spanLinksExtractor SpanLinksExtractor[REQUEST]) *Builder[REQUEST, RESPONSE] {
+	b.SpanLinksExtractor = spanLinksExtractor
+	return b
+}
+
 func (b *Builder[REQUEST, RESPONSE]) AddAttributesExtractor(attributesExtractor ...AttributesExtractor[REQUEST, RESPONSE]) *Builder[REQUEST, RESPONSE] {
 	b.AttributesExtractors = append(b.AttributesExtractors, attributesExtractor...)
 	return b
@@ -106,31 +110,35 @@ func (b *Builder[REQUEST, RESPONSE]) BuildInstrumenter() *InternalInstrumenter[R
 			trace.WithInstrumentationVersion(b.Scope.Version),
 			trace.WithSchemaURL(b.Scope.SchemaURL))
 	return &InternalInstrumenter[REQUEST, RESPONSE]{
-		enabler:              b.Enabler,
-		spanNameExtractor:    b.SpanNameExtractor,
-		spanKindExtractor:    b.SpanKindExtractor,
-		spanStatusExtractor:  b.SpanStatusExtractor,
-		attributesExtractors: b.AttributesExtractors,
-		operationListeners:   b.OperationListeners,
-		contextCustomizers:   b.ContextCustomizers,
-		spanSuppressor:       b.buildSpanSuppressor(),
-		tracer:               tracer,
-		instVersion:          b.InstVersion,
+		enabler:                b.Enabler,
+		spanNameExtractor:      b.SpanNameExtractor,
+		spanKindExtractor:      b.SpanKindExtractor,
+		spanStatusExtractor:    b.SpanStatusExtractor,
+		spanLinksExtractor:     b.SpanLinksExtractor,
+		attributesExtractors:   b.AttributesExtractors,
+		operationListeners:     b.OperationListeners,
+		contextCustomizers:     b.ContextCustomizers,
+		spanSuppressor:         b.buildSpanSuppressor(),
+		suppressedSpanRecorder: getSuppressedSpanRecorderFromEnv(),
+		tracer:                 tracer,
+		instVersion:            b.InstVersion,
 	}
 }
 
 func (b *Builder[REQUEST, RESPONSE]) BuildInstrumenterWithTracer(tracer trace.Tracer) *InternalInstrumenter[REQUEST, RESPONSE] {
 	return &InternalInstrumenter[REQUEST, RESPONSE]{
-		enabler:              b.Enabler,
-		spanNameExtractor:    b.SpanNameExtractor,
-		spanKindExtractor:    b.SpanKindExtractor,
-		spanStatusExtractor:  b.SpanStatusExtractor,
-		attributesExtractors: b.AttributesExtractors,
-		operationListeners:   b.OperationListeners,
-		contextCustomizers:   b.ContextCustomizers,
-		spanSuppressor:       b.buildSpanSuppressor(),
-		tracer:               tracer,
-		instVersion:          b.InstVersion,
+		enabler:                b.Enabler,
+		spanNameExtractor:      b.SpanNameExtractor,
+		spanKindExtractor:      b.SpanKindExtractor,
+		spanStatusExtractor:    b.SpanStatusExtractor,
+		spanLinksExtractor:     b.SpanLinksExtractor,
+		attributesExtractors:   b.AttributesExtractors,
+		operationListeners:     b.OperationListeners,
+		contextCustomizers:     b.ContextCustomizers,
+		spanSuppressor:         b.buildSpanSuppressor(),
+		suppressedSpanRecorder: getSuppressedSpanRecorderFromEnv(),
+		tracer:                 tracer,
+		instVersion:            b.InstVersion,
 	}
 }
 
@@ -141,16 +149,18 @@ func (b *Builder[REQUEST, RESPONSE]) BuildPropagatingToDownstreamInstrumenter(ca
 			trace.WithSchemaURL(b.Scope.SchemaURL))
 	return &PropagatingToDownstreamInstrumenter[REQUEST, RESPONSE]{
 		base: InternalInstrumenter[REQUEST, RESPONSE]{
-			enabler:              b.Enabler,
-			spanNameExtractor:    b.SpanNameExtractor,
-			spanKindExtractor:    b.SpanKindExtractor,
-			spanStatusExtractor:  b.SpanStatusExtractor,
-			attributesExtractors: b.AttributesExtractors,
-			operationListeners:   b.OperationListeners,
-			contextCustomizers:   b.ContextCustomizers,
-			spanSuppressor:       b.buildSpanSuppressor(),
-			tracer:               tracer,
-			instVersion:          b.InstVersion,
+			enabler:                b.Enabler,
+			spanNameExtractor:      b.SpanNameExtractor,
+			spanKindExtractor:      b.SpanKindExtractor,
+			spanStatusExtractor:    b.SpanStatusExtractor,
+			spanLinksExtractor:     b.SpanLinksExtractor,
+			attributesExtractors:   b.AttributesExtractors,
+			operationListeners:     b.OperationListeners,
+			contextCustomizers:     b.ContextCustomizers,
+			spanSuppressor:         b.buildSpanSuppressor(),
+			suppressedSpanRecorder: getSuppressedSpanRecorderFromEnv(),
+			tracer:                 tracer,
+			instVersion:            b.InstVersion,
 		},
 		carrierGetter: carrierGetter,
 		prop:          prop,
@@ -164,15 +174,18 @@ func (b *Builder[REQUEST, RESPONSE]) BuildPropagatingFromUpstreamInstrumenter(ca
 			trace.WithSchemaURL(b.Scope.SchemaURL))
 	return &PropagatingFromUpstreamInstrumenter[REQUEST, RESPONSE]{
 		base: InternalInstrumenter[REQUEST, RESPONSE]{
-			enabler:              b.Enabler,
-			spanNameExtractor:    b.SpanNameExtractor,
-			spanKindExtractor:    b.SpanKindExtractor,
-			spanStatusExtractor:  b.SpanStatusExtractor,
-			attributesExtractors: b.AttributesExtractors,
-			operationListeners:   b.OperationListeners,
-			spanSuppressor:       b.buildSpanSuppressor(),
-			tracer:               tracer,
-			instVersion:          b.InstVersion,
+			enabler:                b.Enabler,
+			spanNameExtractor:      b.SpanNameExtractor,
+			spanKindExtractor:      b.SpanKindExtractor,
+			spanStatusExtractor:    b.SpanStatusExtractor,
+			spanLinksExtractor:     b.SpanLinksExtractor,
+			attributesExtractors:   b.AttributesExtractors,
+			operationListeners:     b.OperationListeners,
+			contextCustomizers:     b.ContextCustomizers,
+			spanSuppressor:         b.buildSpanSuppressor(),
+			suppressedSpanRecorder: getSuppressedSpanRecorderFromEnv(),
+			tracer:                 tracer,
+			instVersion:            b.InstVersion,
 		},
 		carrierGetter: carrierGetter,
 		prop:          prop,
@@ -331,3 +331,76 @@ type SpanRecorder struct {
 	started []sdktrace.ReadWriteSpan
 	ended   []sdktrace.ReadOnlySpan
 }
+
+type testLinksExtractor struct {
+	links []trace.Link
+}
+
+func (t testLinksExtractor) Extract(parentContext context.Context, request testRequest) []trace.Link {
+	return t.links
+}
+
+func TestSpanLinks(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	_, upstream := tp.Tracer("test").Start(context.Background(), "upstream")
+	builder := Builder[testRequest, testResponse]{}
+	builder.Init().
+		SetSpanNameExtractor(testNameExtractor{}).
+		SetSpanKindExtractor(&AlwaysConsumerExtractor[testRequest]{}).
+		SetSpanLinksExtractor(testLinksExtractor{links: []trace.Link{{SpanContext: upstream.SpanContext()}}})
+	instrumenter := builder.BuildInstrumenterWithTracer(tp.Tracer("test"))
+	ctx := instrumenter.Start(context.Background(), testRequest{})
+	instrumenter.End(ctx, testRequest{}, testResponse{}, nil)
+	spans := sr.Ended()
+	if len(spans) != 1 || len(spans[0].Links()) != 1 {
+		t.Fatalf("expected one span with one link, got %v", spans)
+	}
+	if spans[0].Links()[0].SpanContext.SpanID() != upstream.SpanContext().SpanID() {
+		t.Fatal("unexpected link")
+	}
+}
+
+func TestNoSpanLinkToParentMessage(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	originalTP := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	defer otel.SetTracerProvider(originalTP)
+
+	_, producer := tp.Tracer("test").Start(context.Background(), "producer")
+	message := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(trace.ContextWithSpan(context.Background(), producer), message)
+	carriers := func(request testRequest) []propagation.TextMapCarrier {
+		return []propagation.TextMapCarrier{message}
+	}
+	builder := Builder[testRequest, testResponse]{}
+	builder.Init().
+		SetSpanNameExtractor(testNameExtractor{}).
+		SetSpanKindExtractor(&AlwaysConsumerExtractor[testRequest]{}).
+		SetSpanLinksExtractor(&PropagatorSpanLinksExtractor[testRequest]{CarriersGetter: carriers, Propagator: propagation.TraceContext{}})
+	consumer := builder.BuildPropagatingFromUpstreamInstrumenter(func(request testRequest) propagation.TextMapCarrier {
+		return message
+	}, propagation.TraceContext{})
+	ctx := consumer.Start(context.Background(), testRequest{})
+	consumer.End(ctx, testRequest{}, testResponse{}, nil)
+
+	// the same message processed under a local span, e.g. in a batch
+	local := builder.BuildInstrumenterWithTracer(tp.Tracer("test"))
+	localCtx, batch := tp.Tracer("test").Start(context.Background(), "batch")
+	ctx = local.Start(localCtx, testRequest{})
+	local.End(ctx, testRequest{}, testResponse{}, nil)
+	batch.End()
+
+	spans := sr.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("expected 3 spans, got %d", len(spans))
+	}
+	if spans[0].Parent().SpanID() != producer.SpanContext().SpanID() || len(spans[0].Links()) != 0 {
+		t.Fatalf("expected the span parented to the message not to link it, got %v", spans[0].Links())
+	}
+	if spans[1].Parent().SpanID() != batch.SpanContext().SpanID() || len(spans[1].Links()) != 1 ||
+		spans[1].Links()[0].SpanContext.SpanID() != producer.SpanContext().SpanID() {
+		t.Fatalf("expected the span parented elsewhere to link the message, got %v", spans[1].Links())
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumenter
+
+import (
+	"context"
+	"os"
+	"runtime/pprof"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// PprofLabelsEnabledEnv set to "true" labels the goroutine serving a SERVER or
+// CONSUMER span with its trace_id and span_id, so that the cpu samples can be
+// filtered by trace in the profiles.
+const PprofLabelsEnabledEnv = "OTEL_INSTRUMENTATION_PPROF_LABELS_ENABLED"
+
+const (
+	traceIdLabel = "trace_id"
+	spanIdLabel  = "span_id"
+)
+
+// profileIdsKey lists the profiles covering a long local root span
+const profileIdsKey = attribute.Key("pprof.profile.ids")
+
+var pprofLabelsEnabled = pprofLabelsEnabledFromEnv()
+
+func pprofLabelsEnabledFromEnv() bool {
+	enabled, err := strconv.ParseBool(os.Getenv(PprofLabelsEnabledEnv))
+	return err == nil && enabled
+}
+
+// LocalRootSpanProfiler collects cpu profiles in process, the ids of the
+// profiles overlapping a local root span longer than Threshold are added to
+// the span.
+type LocalRootSpanProfiler interface {
+	Threshold() time.Duration
+	ProfileIds(start, end time.Time) []string
+}
+
+type profilerHolder struct {
+	profiler LocalRootSpanProfiler
+}
+
+var localRootSpanProfiler atomic.Pointer[profilerHolder]
+
+// SetLocalRootSpanProfiler sets the profiler consulted when a local root span
+// ends, nil removes it.
+func SetLocalRootSpanProfiler(p LocalRootSpanProfiler) {
+	if p == nil {
+		localRootSpanProfiler.Store(nil)
+		return
+	}
+	localRootSpanProfiler.Store(&profilerHolder{profiler: p})
+}
+
+type pprofLabelsKey struct{}
+
+// pprofLabels remembers the context whose labels were on the goroutine before
+// the span started.
+type pprofLabels struct {
+	spanId trace.SpanID
+	parent context.Context
+}
+
+// setPprofLabels labels the current goroutine with the span ids, the labels of
+// the parent context are kept. Goroutines started while the span is active
+// inherit the labels.
+func setPprofLabels(parentContext, ctx context.Context, spanKind trace.SpanKind, span trace.Span) context.Context {
+	if !pprofLabelsEnabled || (spanKind != trace.SpanKindServer && spanKind != trace.SpanKindConsumer) {
+		return ctx
+	}
+	sc := span.SpanContext()
+	if !sc.IsValid() {
+		return ctx
+	}
+	ctx = context.WithValue(ctx, pprofLabelsKey{}, &pprofLabels{spanId: sc.SpanID(), parent: parentContext})
+	ctx = pprof.WithLabels(ctx, pprof.Labels(traceIdLabel, sc.TraceID().String(), spanIdLabel, sc.SpanID().String()))
+	pprof.SetGoroutineLabels(ctx)
+	return ctx
+}
+
+// restorePprofLabels puts back the labels of the parent context, the nested
+// spans sharing the context of a labeled span leave the labels alone.
+func restorePprofLabels(ctx context.Context, span trace.Span) {
+	labels, ok := ctx.Value(pprofLabelsKey{}).(*pprofLabels)
+	if !ok || labels.spanId != span.SpanContext().SpanID() {
+		return
+	}
+	pprof.SetGoroutineLabels(labels.parent)
+}
+
+// readOnlySpan is implemented by the spans of the sdk
+type readOnlySpan interface {
+	Parent() trace.SpanContext
+	StartTime() time.Time
+}
+
+// attachProfileIds adds the ids of the collected profiles to a local root
+// span running longer than the threshold of the profiler.
+func attachProfileIds(span trace.Span, end time.Time) {
+	holder := localRootSpanProfiler.Load()
+	if holder == nil || !span.IsRecording() {
+		return
+	}
+	s, ok := span.(readOnlySpan)
+	if !ok {
+		return
+	}
+	// the span is a local root when its parent lives in another process
+	if parent := s.Parent(); parent.IsValid() && !parent.IsRemote() {
+		return
+	}
+	start := s.StartTime()
+	if end.Sub(start) < holder.profiler.Threshold() {
+		return
+	}
+	if ids := holder.profiler.ProfileIds(start, end); len(ids) > 0 {
+		span.SetAttributes(profileIdsKey.StringSlice(ids))
+	}
+}
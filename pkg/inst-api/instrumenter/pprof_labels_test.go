@@ -0,0 +1,133 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumenter
+
+import (
+	"bytes"
+	"context"
+	"runtime/pprof"
+	"strings"
+	"testing"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func enablePprofLabels(t *testing.T) {
+	old := pprofLabelsEnabled
+	pprofLabelsEnabled = true
+	t.Cleanup(func() { pprofLabelsEnabled = old })
+}
+
+// goroutineLabelled tells whether any goroutine carries the span id label
+func goroutineLabelled(t *testing.T, spanId string) bool {
+	var buf bytes.Buffer
+	if err := pprof.Lookup("goroutine").WriteTo(&buf, 1); err != nil {
+		t.Fatal(err)
+	}
+	return strings.Contains(buf.String(), `"span_id":"`+spanId+`"`)
+}
+
+func buildTestInstrumenter(kind SpanKindExtractor[testRequest], sr *tracetest.SpanRecorder) Instrumenter[testRequest, testResponse] {
+	builder := Builder[testRequest, testResponse]{}
+	builder.Init().
+		SetSpanNameExtractor(testNameExtractor{}).
+		SetSpanKindExtractor(kind)
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)).Tracer("test-tracer")
+	return builder.BuildInstrumenterWithTracer(tracer)
+}
+
+func TestPprofLabelsOnServerSpan(t *testing.T) {
+	enablePprofLabels(t)
+	parent := pprof.WithLabels(context.Background(), pprof.Labels("handler", "test"))
+	pprof.SetGoroutineLabels(parent)
+	defer pprof.SetGoroutineLabels(context.Background())
+
+	inst := buildTestInstrumenter(&AlwaysServerExtractor[testRequest]{}, tracetest.NewSpanRecorder())
+	ctx := inst.Start(parent, testRequest{})
+	sc := trace.SpanContextFromContext(ctx)
+	if v, _ := pprof.Label(ctx, "trace_id"); v != sc.TraceID().String() {
+		t.Fatalf("expected the trace_id label, got %s", v)
+	}
+	if v, _ := pprof.Label(ctx, "handler"); v != "test" {
+		t.Fatalf("expected the parent labels to be kept, got %s", v)
+	}
+	if !goroutineLabelled(t, sc.SpanID().String()) {
+		t.Fatal("expected the goroutine to be labelled")
+	}
+
+	// a nested client span shares the labels of the server span
+	client := buildTestInstrumenter(&AlwaysClientExtractor[testRequest]{}, tracetest.NewSpanRecorder())
+	client.End(client.Start(ctx, testRequest{}), testRequest{}, testResponse{}, nil)
+	if !goroutineLabelled(t, sc.SpanID().String()) {
+		t.Fatal("expected the nested span to leave the labels alone")
+	}
+
+	inst.End(ctx, testRequest{}, testResponse{}, nil)
+	if goroutineLabelled(t, sc.SpanID().String()) {
+		t.Fatal("expected the labels to be restored")
+	}
+}
+
+func TestPprofLabelsDisabled(t *testing.T) {
+	inst := buildTestInstrumenter(&AlwaysServerExtractor[testRequest]{}, tracetest.NewSpanRecorder())
+	ctx := inst.Start(context.Background(), testRequest{})
+	defer inst.End(ctx, testRequest{}, testResponse{}, nil)
+	if _, ok := pprof.Label(ctx, "trace_id"); ok {
+		t.Fatal("expected no labels by default")
+	}
+}
+
+type testProfiler struct{}
+
+func (testProfiler) Threshold() time.Duration {
+	return 50 * time.Millisecond
+}
+
+func (testProfiler) ProfileIds(start, end time.Time) []string {
+	return []string{"profile-1"}
+}
+
+func TestAttachProfileIds(t *testing.T) {
+	SetLocalRootSpanProfiler(testProfiler{})
+	defer SetLocalRootSpanProfiler(nil)
+	sr := tracetest.NewSpanRecorder()
+	inst := buildTestInstrumenter(&AlwaysServerExtractor[testRequest]{}, sr)
+	start := time.Now()
+	// the short span and the nested long span are left alone
+	inst.StartAndEnd(context.Background(), testRequest{}, testResponse{}, nil, start, start.Add(time.Millisecond))
+	ctx := inst.Start(context.Background(), testRequest{})
+	inst.StartAndEnd(ctx, testRequest{}, testResponse{}, nil, start, start.Add(2*time.Second))
+	time.Sleep(60 * time.Millisecond)
+	inst.End(ctx, testRequest{}, testResponse{}, nil)
+
+	spans := sr.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("expected 3 spans, got %d", len(spans))
+	}
+	for i, span := range spans {
+		var ids []string
+		for _, attr := range span.Attributes() {
+			if attr.Key == profileIdsKey {
+				ids = attr.Value.AsStringSlice()
+			}
+		}
+		if expected := i == 2; expected != (len(ids) == 1 && ids[0] == "profile-1") {
+			t.Fatalf("unexpected profile ids %v on span %d", ids, i)
+		}
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 	"os"
+	"strings"
 )
 
 type SpanSuppressorStrategy interface {
@@ -49,14 +50,35 @@ type SpanKindSuppressor struct {
 	delegates map[trace.SpanKind]SpanSuppressor
 }
 
+// getSpanSuppressionStrategyValues reads the comma separated strategy, e.g.
+// "span-kind,event" suppresses nested spans of the same kind and records them
+// as events of the parent span.
+func getSpanSuppressionStrategyValues() []string {
+	return strings.Split(os.Getenv("OTEL_INSTRUMENTATION_EXPERIMENTAL_SPAN_SUPPRESSION_STRATEGY"), ",")
+}
+
 func getSpanSuppressionStrategyFromEnv() SpanSuppressorStrategy {
-	suppressionStrategy := os.Getenv("OTEL_INSTRUMENTATION_EXPERIMENTAL_SPAN_SUPPRESSION_STRATEGY")
-	switch suppressionStrategy {
-	case "none":
-		return &NoneStrategy{}
-	case "span-kind":
-		return &SpanKindStrategy{}
-	default:
-		return &SemConvStrategy{}
+	for _, suppressionStrategy := range getSpanSuppressionStrategyValues() {
+		switch strings.TrimSpace(suppressionStrategy) {
+		case "none":
+			return &NoneStrategy{}
+		case "span-kind":
+			return &SpanKindStrategy{}
+		}
+	}
+	return &SemConvStrategy{}
+}
+
+// getSuppressedSpanRecorderFromEnv returns nil when the suppressed spans are
+// not recorded, in which case no span is suppressed by Start either.
+func getSuppressedSpanRecorderFromEnv() SuppressedSpanRecorder {
+	for _, suppressionStrategy := range getSpanSuppressionStrategyValues() {
+		switch strings.TrimSpace(suppressionStrategy) {
+		case "merge":
+			return &AttributesMergingRecorder{}
+		case "event":
+			return &SpanEventRecorder{}
+		}
 	}
+	return nil
 }
@@ -0,0 +1,124 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumenter
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	ottrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const suppressed_spans_metric = "otel.instrumentation.suppressed_spans"
+
+const (
+	suppressedSpanKindKey     = attribute.Key("otel.suppressed_span.kind")
+	suppressedSpanDurationKey = attribute.Key("otel.suppressed_span.duration_ms")
+)
+
+// SuppressedSpanRecorder keeps what a suppressed span would have recorded on
+// the surviving parent span instead of silently dropping it.
+type SuppressedSpanRecorder interface {
+	Record(parent trace.Span, s *SuppressedSpan)
+}
+
+// SuppressedSpan is what the suppressed span would have recorded, it is
+// stored in the context returned by Start in place of the span.
+type SuppressedSpan struct {
+	Name       string
+	Kind       trace.SpanKind
+	StartTime  time.Time
+	EndTime    time.Time
+	Attributes []attribute.KeyValue
+	Err        error
+}
+
+type suppressedSpanKey struct{}
+
+func suppressedSpanFromContext(ctx context.Context) *SuppressedSpan {
+	s, _ := ctx.Value(suppressedSpanKey{}).(*SuppressedSpan)
+	return s
+}
+
+// AttributesMergingRecorder merges the attributes of the suppressed span
+// into the parent, the attributes already set on the parent are kept.
+type AttributesMergingRecorder struct{}
+
+func (r *AttributesMergingRecorder) Record(parent trace.Span, s *SuppressedSpan) {
+	existing := make(map[attribute.Key]bool)
+	if ro, ok := parent.(ottrace.ReadOnlySpan); ok {
+		for _, attr := range ro.Attributes() {
+			existing[attr.Key] = true
+		}
+	}
+	attrs := make([]attribute.KeyValue, 0, len(s.Attributes))
+	for _, attr := range s.Attributes {
+		if !existing[attr.Key] {
+			existing[attr.Key] = true
+			attrs = append(attrs, attr)
+		}
+	}
+	// error.type comes from the error type getter of the operation when it
+	// has one, otherwise the type name keeps it low-cardinality
+	if s.Err != nil && !existing[semconv.ErrorTypeKey] {
+		attrs = append(attrs, semconv.ErrorTypeKey.String(fmt.Sprintf("%T", s.Err)))
+	}
+	parent.SetAttributes(attrs...)
+	countSuppressedSpan(s.Kind)
+}
+
+// SpanEventRecorder records the suppressed span as an event of the parent,
+// the event is named after the suppressed span.
+type SpanEventRecorder struct{}
+
+func (r *SpanEventRecorder) Record(parent trace.Span, s *SuppressedSpan) {
+	attrs := make([]attribute.KeyValue, 0, len(s.Attributes)+3)
+	attrs = append(attrs,
+		suppressedSpanKindKey.String(s.Kind.String()),
+		suppressedSpanDurationKey.Float64(float64(s.EndTime.Sub(s.StartTime))/float64(time.Millisecond)))
+	attrs = append(attrs, s.Attributes...)
+	if s.Err != nil {
+		attrs = append(attrs, semconv.ExceptionMessage(s.Err.Error()))
+	}
+	parent.AddEvent(s.Name, trace.WithTimestamp(s.StartTime), trace.WithAttributes(attrs...))
+	countSuppressedSpan(s.Kind)
+}
+
+var (
+	suppressedSpansOnce    sync.Once
+	suppressedSpansCounter metric.Int64Counter
+)
+
+func countSuppressedSpan(kind trace.SpanKind) {
+	suppressedSpansOnce.Do(func() {
+		// the global MeterProvider delegates to the one set up later
+		counter, err := otel.Meter("opentelemetry-go-auto-instrumentation").Int64Counter(suppressed_spans_metric,
+			metric.WithDescription("Number of spans suppressed by the span suppression strategy."))
+		if err == nil {
+			suppressedSpansCounter = counter
+		}
+	})
+	if suppressedSpansCounter != nil {
+		suppressedSpansCounter.Add(context.Background(), 1,
+			metric.WithAttributes(attribute.String("span.kind", kind.String())))
+	}
+}
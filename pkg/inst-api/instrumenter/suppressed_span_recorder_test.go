@@ -0,0 +1,151 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumenter
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/utils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type childAttributesExtractor struct{}
+
+func (c childAttributesExtractor) OnStart(attributes []attribute.KeyValue, parentContext context.Context, request testRequest) ([]attribute.KeyValue, context.Context) {
+	return append(attributes, attribute.String("testAttribute", "childValue"), attribute.String("childAttribute", "childValue")), parentContext
+}
+
+func (c childAttributesExtractor) OnEnd(attributes []attribute.KeyValue, context context.Context, request testRequest, response testResponse, err error) ([]attribute.KeyValue, context.Context) {
+	return append(attributes, attribute.String("childEndAttribute", response.status)), context
+}
+
+func runSuppressed(t *testing.T, strategy string) sdktrace.ReadOnlySpan {
+	t.Setenv("OTEL_INSTRUMENTATION_EXPERIMENTAL_SPAN_SUPPRESSION_STRATEGY", strategy)
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).
+		Tracer(utils.NET_HTTP_CLIENT_SCOPE_NAME)
+	parent := (&Builder[testRequest, testResponse]{}).Init().
+		SetSpanNameExtractor(testNameExtractor{}).
+		SetSpanKindExtractor(&AlwaysClientExtractor[testRequest]{}).
+		AddAttributesExtractor(testAttributesExtractor{}).
+		SetInstrumentationScope(instrumentation.Scope{Name: utils.NET_HTTP_CLIENT_SCOPE_NAME}).
+		BuildInstrumenterWithTracer(tracer)
+	child := (&Builder[testRequest, testResponse]{}).Init().
+		SetSpanNameExtractor(testNameExtractor{}).
+		SetSpanKindExtractor(&AlwaysClientExtractor[testRequest]{}).
+		AddAttributesExtractor(childAttributesExtractor{}).
+		BuildInstrumenterWithTracer(tracer)
+
+	ctx := parent.Start(context.Background(), testRequest{})
+	if !child.ShouldStart(ctx, testRequest{}) {
+		t.Fatal("suppressed span should still be started to be recorded")
+	}
+	childCtx := child.Start(ctx, testRequest{})
+	child.End(childCtx, testRequest{}, testResponse{status: "ok"}, errors.New("timeout"))
+	parent.End(ctx, testRequest{}, testResponse{}, nil)
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected the child span to be suppressed, got %d spans", len(spans))
+	}
+	return spans[0]
+}
+
+func TestRecordSuppressedSpanAsEvent(t *testing.T) {
+	span := runSuppressed(t, "span-kind,event")
+	if len(span.Events()) != 1 || span.Events()[0].Name != "test" {
+		t.Fatalf("expected one event for the suppressed span, got %v", span.Events())
+	}
+	attrs := attribute.NewSet(span.Events()[0].Attributes...)
+	if v, _ := attrs.Value("childEndAttribute"); v.AsString() != "ok" {
+		t.Fatal("end attributes should be recorded")
+	}
+	if v, _ := attrs.Value("exception.message"); v.AsString() != "timeout" {
+		t.Fatal("error should be recorded")
+	}
+}
+
+func TestMergeSuppressedSpanAttributes(t *testing.T) {
+	span := runSuppressed(t, "span-kind,merge")
+	attrs := attribute.NewSet(span.Attributes()...)
+	if v, _ := attrs.Value("testAttribute"); v.AsString() != "testValue" {
+		t.Fatal("attributes of the parent should not be overwritten")
+	}
+	if v, _ := attrs.Value("childAttribute"); v.AsString() != "childValue" {
+		t.Fatal("attributes of the suppressed span should be merged")
+	}
+	if v, _ := attrs.Value("error.type"); v.AsString() != "*errors.errorString" {
+		t.Fatalf("expected the type of the error, got %s", v.AsString())
+	}
+	if len(span.Events()) != 0 {
+		t.Fatal("no event is expected")
+	}
+}
+
+func TestSpanNestedInSuppressedSpan(t *testing.T) {
+	t.Setenv("OTEL_INSTRUMENTATION_EXPERIMENTAL_SPAN_SUPPRESSION_STRATEGY", "span-kind,event")
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer(utils.NET_HTTP_CLIENT_SCOPE_NAME)
+	client := (&Builder[testRequest, testResponse]{}).Init().
+		SetSpanNameExtractor(testNameExtractor{}).
+		SetSpanKindExtractor(&AlwaysClientExtractor[testRequest]{}).
+		AddAttributesExtractor(childAttributesExtractor{}).
+		BuildInstrumenterWithTracer(tracer)
+	internal := (&Builder[testRequest, testResponse]{}).Init().
+		SetSpanNameExtractor(testNameExtractor{}).
+		SetSpanKindExtractor(&AlwaysInternalExtractor[testRequest]{}).
+		BuildInstrumenterWithTracer(tracer)
+
+	ctx := client.Start(context.Background(), testRequest{})
+	suppressedCtx := client.Start(ctx, testRequest{})
+	// a real span started inside the suppressed operation
+	internalCtx := internal.Start(suppressedCtx, testRequest{})
+	internal.End(internalCtx, testRequest{}, testResponse{status: "internal"}, nil)
+	client.End(suppressedCtx, testRequest{}, testResponse{status: "suppressed"}, nil)
+	client.End(ctx, testRequest{}, testResponse{status: "parent"}, nil)
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected the nested span and the parent to be ended, got %d spans", len(spans))
+	}
+	if spans[0].SpanKind() != trace.SpanKindInternal || spans[1].SpanKind() != trace.SpanKindClient {
+		t.Fatalf("unexpected spans %v", spans)
+	}
+	// the suppressed span is recorded once, with its own end attributes
+	events := spans[1].Events()
+	if len(events) != 1 {
+		t.Fatalf("expected one event for the suppressed span, got %v", events)
+	}
+	attrs := attribute.NewSet(events[0].Attributes...)
+	if v, _ := attrs.Value("childEndAttribute"); v.AsString() != "suppressed" {
+		t.Fatalf("unexpected suppressed span attribute %s", v.AsString())
+	}
+}
+
+func TestSuppressedSpanRecorderFromEnv(t *testing.T) {
+	t.Setenv("OTEL_INSTRUMENTATION_EXPERIMENTAL_SPAN_SUPPRESSION_STRATEGY", "span-kind")
+	if getSuppressedSpanRecorderFromEnv() != nil {
+		t.Fatal("suppressed spans should not be recorded by default")
+	}
+	if _, ok := getSpanSuppressionStrategyFromEnv().(*SpanKindStrategy); !ok {
+		t.Fatal("expected span-kind strategy")
+	}
+}
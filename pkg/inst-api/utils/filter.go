@@ -27,6 +27,9 @@ type SpanNameFilter interface {
 type DefaultUrlFilter struct {
 }
 
+// FilterUrl consults the http filter configured by
+// OTEL_INSTRUMENTATION_HTTP_* or SetHttpFilter, the method and the user agent
+// are unknown here, see HttpFilter.FilterUrl.
 func (d DefaultUrlFilter) FilterUrl(url *url.URL) bool {
-	return false
+	return FilterHttpUrl(url)
 }
@@ -0,0 +1,299 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync/atomic"
+)
+
+// the comma separated lists configuring the http filter from env, the paths
+// and the user agents are globs, see HttpFilterRule. The included paths only
+// apply to the server side, the exclusions apply to both the server and the
+// client side.
+const (
+	httpIncludedPathsEnv      = "OTEL_INSTRUMENTATION_HTTP_INCLUDED_PATHS"
+	httpExcludedPathsEnv      = "OTEL_INSTRUMENTATION_HTTP_EXCLUDED_PATHS"
+	httpExcludedMethodsEnv    = "OTEL_INSTRUMENTATION_HTTP_EXCLUDED_METHODS"
+	httpExcludedUserAgentsEnv = "OTEL_INSTRUMENTATION_HTTP_EXCLUDED_USER_AGENTS"
+)
+
+// HttpFilterRule matches a request when every non-empty condition matches, a
+// condition matches when any of its values matches.
+type HttpFilterRule struct {
+	// Paths are globs of the url path, "*" matches within a path segment and
+	// "**" matches across segments, e.g. "/api/*/health" or "/static/**".
+	Paths []string `yaml:"paths"`
+	// PathRegexes are matched against the url path as well, a path matching
+	// either a glob or a regex satisfies the path condition.
+	PathRegexes []string `yaml:"path_regexes"`
+	// Methods are compared case-insensitively.
+	Methods []string `yaml:"methods"`
+	// UserAgents are case-insensitive globs where "*" matches anything, e.g.
+	// "kube-probe/*".
+	UserAgents []string `yaml:"user_agents"`
+}
+
+type httpFilterMatcher struct {
+	paths      []*regexp.Regexp
+	methods    []string
+	userAgents []*regexp.Regexp
+}
+
+// HttpFilter skips the requests matching any exclude rule, and when there is
+// an include rule, the inbound requests matching none of the include rules.
+// The include rules describe the routes served by the process, they do not
+// apply to the outbound requests.
+type HttpFilter struct {
+	include []httpFilterMatcher
+	exclude []httpFilterMatcher
+}
+
+func NewHttpFilter(include, exclude []HttpFilterRule) (*HttpFilter, error) {
+	f := &HttpFilter{}
+	var err error
+	if f.include, err = newHttpFilterMatchers(include); err != nil {
+		return nil, err
+	}
+	if f.exclude, err = newHttpFilterMatchers(exclude); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func newHttpFilterMatchers(rules []HttpFilterRule) ([]httpFilterMatcher, error) {
+	matchers := make([]httpFilterMatcher, 0, len(rules))
+	for _, rule := range rules {
+		m := httpFilterMatcher{}
+		for _, glob := range rule.Paths {
+			m.paths = append(m.paths, globToRegexp(glob, "[^/]*", ""))
+		}
+		for _, expr := range rule.PathRegexes {
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid path regex %q: %w", expr, err)
+			}
+			m.paths = append(m.paths, re)
+		}
+		for _, method := range rule.Methods {
+			m.methods = append(m.methods, strings.ToUpper(strings.TrimSpace(method)))
+		}
+		for _, glob := range rule.UserAgents {
+			m.userAgents = append(m.userAgents, globToRegexp(glob, ".*", "(?i)"))
+		}
+		matchers = append(matchers, m)
+	}
+	return matchers, nil
+}
+
+// globToRegexp translates the glob, "**" always matches across path segments.
+func globToRegexp(glob string, star string, flags string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString(flags + "^")
+	for i := 0; i < len(glob); i++ {
+		switch {
+		case strings.HasPrefix(glob[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case glob[i] == '*':
+			b.WriteString(star)
+		case glob[i] == '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
+
+func (m *httpFilterMatcher) match(method string, path string, userAgent string) bool {
+	if len(m.paths) > 0 && !anyMatch(m.paths, path) {
+		return false
+	}
+	if len(m.methods) > 0 {
+		matched := false
+		for _, expected := range m.methods {
+			if strings.EqualFold(expected, method) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if len(m.userAgents) > 0 && !anyMatch(m.userAgents, userAgent) {
+		return false
+	}
+	return true
+}
+
+// matchPath matches the path condition only, the conditions on the method
+// and the user agent are taken as satisfied when matchUnknown is set and as
+// unsatisfied otherwise.
+func (m *httpFilterMatcher) matchPath(path string, matchUnknown bool) bool {
+	if len(m.paths) > 0 && !anyMatch(m.paths, path) {
+		return false
+	}
+	if len(m.methods) > 0 || len(m.userAgents) > 0 {
+		return matchUnknown
+	}
+	return true
+}
+
+func anyMatch(res []*regexp.Regexp, s string) bool {
+	for _, re := range res {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterRequest returns true when the inbound request should not be
+// instrumented.
+func (f *HttpFilter) FilterRequest(method string, u *url.URL, userAgent string) bool {
+	return f.filter(method, u, userAgent, true)
+}
+
+// FilterClientRequest returns true when the outbound request should not be
+// instrumented, only the exclude rules are honored.
+func (f *HttpFilter) FilterClientRequest(method string, u *url.URL, userAgent string) bool {
+	return f.filter(method, u, userAgent, false)
+}
+
+func (f *HttpFilter) filter(method string, u *url.URL, userAgent string, server bool) bool {
+	if f == nil || (len(f.include) == 0 && len(f.exclude) == 0) {
+		return false
+	}
+	path := ""
+	if u != nil {
+		path = u.Path
+	}
+	for i := range f.exclude {
+		if f.exclude[i].match(method, path, userAgent) {
+			return true
+		}
+	}
+	if !server || len(f.include) == 0 {
+		return false
+	}
+	for i := range f.include {
+		if f.include[i].match(method, path, userAgent) {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterUrl returns true when the inbound request to u should not be
+// instrumented while its method and user agent are unknown. The include rules
+// are matched on their paths only, the exclude rules conditioned on the
+// method or the user agent never match.
+func (f *HttpFilter) FilterUrl(u *url.URL) bool {
+	if f == nil || (len(f.include) == 0 && len(f.exclude) == 0) {
+		return false
+	}
+	path := ""
+	if u != nil {
+		path = u.Path
+	}
+	for i := range f.exclude {
+		if f.exclude[i].matchPath(path, false) {
+			return true
+		}
+	}
+	if len(f.include) == 0 {
+		return false
+	}
+	for i := range f.include {
+		if f.include[i].matchPath(path, true) {
+			return false
+		}
+	}
+	return true
+}
+
+// HttpFilterFromEnv builds the filter from OTEL_INSTRUMENTATION_HTTP_*, each
+// variable is a rule of its own.
+func HttpFilterFromEnv() (*HttpFilter, error) {
+	var include, exclude []HttpFilterRule
+	if paths := splitEnv(httpIncludedPathsEnv); len(paths) > 0 {
+		include = append(include, HttpFilterRule{Paths: paths})
+	}
+	if paths := splitEnv(httpExcludedPathsEnv); len(paths) > 0 {
+		exclude = append(exclude, HttpFilterRule{Paths: paths})
+	}
+	if methods := splitEnv(httpExcludedMethodsEnv); len(methods) > 0 {
+		exclude = append(exclude, HttpFilterRule{Methods: methods})
+	}
+	if userAgents := splitEnv(httpExcludedUserAgentsEnv); len(userAgents) > 0 {
+		exclude = append(exclude, HttpFilterRule{UserAgents: userAgents})
+	}
+	return NewHttpFilter(include, exclude)
+}
+
+func splitEnv(key string) []string {
+	var values []string
+	for _, v := range strings.Split(os.Getenv(key), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+var configuredHttpFilter atomic.Pointer[HttpFilter]
+
+func init() {
+	f, err := HttpFilterFromEnv()
+	if err != nil {
+		log.Printf("failed to configure the http filter: %v", err)
+		return
+	}
+	configuredHttpFilter.Store(f)
+}
+
+// SetHttpFilter replaces the filter honored by the http rules, it can be
+// called at any time to reload the filter, nil filters nothing.
+func SetHttpFilter(f *HttpFilter) {
+	configuredHttpFilter.Store(f)
+}
+
+// FilterHttpRequest returns true when the inbound http request should not be
+// instrumented according to the configured filter, it is used by the server
+// rules.
+func FilterHttpRequest(method string, u *url.URL, userAgent string) bool {
+	return configuredHttpFilter.Load().FilterRequest(method, u, userAgent)
+}
+
+// FilterHttpUrl returns true when the inbound http request to u should not be
+// instrumented according to the configured filter, see HttpFilter.FilterUrl.
+func FilterHttpUrl(u *url.URL) bool {
+	return configuredHttpFilter.Load().FilterUrl(u)
+}
+
+// FilterHttpClientRequest returns true when the outbound http request should
+// not be instrumented, it is used by the client rules and ignores the include
+// rules.
+func FilterHttpClientRequest(method string, u *url.URL, userAgent string) bool {
+	return configuredHttpFilter.Load().FilterClientRequest(method, u, userAgent)
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestHttpFilter(t *testing.T) {
+	f, err := NewHttpFilter(nil, []HttpFilterRule{
+		{Paths: []string{"/health", "/static/**", "/api/*/metrics"}},
+		{PathRegexes: []string{`^/v[0-9]+/ping$`}},
+		{Methods: []string{"options"}},
+		{Paths: []string{"/users/*"}, Methods: []string{"DELETE"}},
+		{UserAgents: []string{"kube-probe/*"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		method    string
+		path      string
+		userAgent string
+		expected  bool
+	}{
+		{"GET", "/health", "", true},
+		{"GET", "/healthz", "", false},
+		{"GET", "/static/js/app.js", "", true},
+		{"GET", "/api/v1/metrics", "", true},
+		{"GET", "/api/v1/v2/metrics", "", false},
+		{"GET", "/v2/ping", "", true},
+		{"OPTIONS", "/users", "", true},
+		{"DELETE", "/users/1", "", true},
+		{"GET", "/users/1", "", false},
+		{"GET", "/users", "Kube-Probe/1.29", true},
+		{"GET", "/users", "curl/8.0", false},
+	}
+	for _, tc := range testCases {
+		if got := f.FilterRequest(tc.method, &url.URL{Path: tc.path}, tc.userAgent); got != tc.expected {
+			t.Errorf("FilterRequest(%s %s %q) = %v; expected %v", tc.method, tc.path, tc.userAgent, got, tc.expected)
+		}
+	}
+}
+
+func TestHttpFilterInclude(t *testing.T) {
+	f, err := NewHttpFilter([]HttpFilterRule{{Paths: []string{"/api/**"}}}, []HttpFilterRule{{Paths: []string{"/api/internal/**"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.FilterUrl(&url.URL{Path: "/api/users"}) {
+		t.Fatal("expected /api/users to be included")
+	}
+	if !f.FilterUrl(&url.URL{Path: "/api/internal/debug"}) || !f.FilterUrl(&url.URL{Path: "/index.html"}) {
+		t.Fatal("expected excluded and not included paths to be filtered")
+	}
+	if _, err = NewHttpFilter(nil, []HttpFilterRule{{PathRegexes: []string{"("}}}); err == nil {
+		t.Fatal("expected an invalid regex to be rejected")
+	}
+}
+
+func TestHttpFilterUrlOnly(t *testing.T) {
+	f, err := NewHttpFilter([]HttpFilterRule{
+		{Paths: []string{"/api/**"}, Methods: []string{"GET", "POST"}},
+		{UserAgents: []string{"grpc-*"}},
+	}, []HttpFilterRule{
+		{Methods: []string{"OPTIONS"}},
+		{Paths: []string{"/api/internal/**"}, UserAgents: []string{"kube-probe/*"}},
+		{Paths: []string{"/api/debug"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		path     string
+		expected bool
+	}{
+		// the include rules match on their paths, the methods are unknown
+		{"/api/users", false},
+		{"/api/internal/state", false},
+		// the rule on the user agent alone may match any path
+		{"/index.html", false},
+		{"/api/debug", true},
+	}
+	for _, tc := range testCases {
+		if got := f.FilterUrl(&url.URL{Path: tc.path}); got != tc.expected {
+			t.Errorf("FilterUrl(%s) = %v; expected %v", tc.path, got, tc.expected)
+		}
+	}
+	f, err = NewHttpFilter([]HttpFilterRule{{Paths: []string{"/api/**"}, Methods: []string{"GET"}}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetHttpFilter(f)
+	defer SetHttpFilter(nil)
+	if (DefaultUrlFilter{}).FilterUrl(&url.URL{Path: "/api/users"}) {
+		t.Fatal("expected the included path to be kept whatever the method")
+	}
+	if !(DefaultUrlFilter{}).FilterUrl(&url.URL{Path: "/index.html"}) {
+		t.Fatal("expected the path not included to be filtered")
+	}
+}
+
+func TestHttpClientFilterIgnoresInclude(t *testing.T) {
+	t.Setenv(httpIncludedPathsEnv, "/api/**")
+	t.Setenv(httpExcludedPathsEnv, "/api/internal/**")
+	f, err := HttpFilterFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetHttpFilter(f)
+	defer SetHttpFilter(nil)
+	if !FilterHttpRequest("GET", &url.URL{Path: "/index.html"}, "") {
+		t.Fatal("expected the inbound request not included to be filtered")
+	}
+	// an outbound call to another service is not one of the served routes
+	if FilterHttpClientRequest("POST", &url.URL{Host: "payment:8080", Path: "/v1/charges"}, "Go-http-client/1.1") {
+		t.Fatal("expected the include rules not to apply to the outbound requests")
+	}
+	if !FilterHttpClientRequest("GET", &url.URL{Path: "/api/internal/debug"}, "") {
+		t.Fatal("expected the exclude rules to apply to the outbound requests")
+	}
+}
+
+func TestHttpFilterFromEnv(t *testing.T) {
+	t.Setenv(httpExcludedPathsEnv, "/health, /metrics")
+	t.Setenv(httpExcludedUserAgentsEnv, "*prometheus*")
+	f, err := HttpFilterFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetHttpFilter(f)
+	defer SetHttpFilter(nil)
+	if !FilterHttpRequest("GET", &url.URL{Path: "/metrics"}, "") || !(DefaultUrlFilter{}).FilterUrl(&url.URL{Path: "/health"}) {
+		t.Fatal("expected the excluded paths to be filtered")
+	}
+	if !FilterHttpRequest("GET", &url.URL{Path: "/users"}, "Prometheus/2.45") {
+		t.Fatal("expected the excluded user agent to be filtered")
+	}
+	if FilterHttpRequest("GET", &url.URL{Path: "/users"}, "") || FilterHttpRequest("GET", nil, "") {
+		t.Fatal("expected /users to be kept")
+	}
+}
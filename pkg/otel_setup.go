@@ -24,11 +24,23 @@ import (
 	"runtime"
 	"strings"
 
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/core/baggage"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/core/config"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/core/diagnostics"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/core/dynamic"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/core/exporter"
 	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/core/meter"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/core/profiling"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/core/spanmetrics"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api-semconv/instrumenter/ai"
 	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api-semconv/instrumenter/db"
 	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api-semconv/instrumenter/experimental"
 	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api-semconv/instrumenter/http"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api-semconv/instrumenter/message"
 	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api-semconv/instrumenter/rpc"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api-semconv/instrumenter/utils"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/instrumenter"
+	instutils "github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/utils"
 	testaccess "github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/testaccess"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	otelruntime "go.opentelemetry.io/contrib/instrumentation/runtime"
@@ -55,20 +67,47 @@ import (
 // your service name: OTEL_SERVICE_NAME
 // your otlp endpoint: OTEL_EXPORTER_OTLP_ENDPOINT OTEL_EXPORTER_OTLP_TRACES_ENDPOINT OTEL_EXPORTER_OTLP_METRICS_ENDPOINT OTEL_EXPORTER_OTLP_LOGS_ENDPOINT
 // your otlp header: OTEL_EXPORTER_OTLP_HEADERS
+// your otlp protocol: OTEL_EXPORTER_OTLP_PROTOCOL OTEL_EXPORTER_OTLP_TRACES_PROTOCOL OTEL_EXPORTER_OTLP_METRICS_PROTOCOL
+// your exporters: OTEL_TRACES_EXPORTER OTEL_METRICS_EXPORTER, a comma-separated list such as "otlp,console"
+// your file exporter: OTEL_EXPORTER_FILE_TRACES_PATH OTEL_EXPORTER_FILE_METRICS_PATH OTEL_EXPORTER_FILE_MAX_SIZE_MB OTEL_EXPORTER_FILE_MAX_BACKUPS
+// your metric views: OTEL_INSTRUMENTATION_METRICS_VIEWS_FILE, the unit of the duration metrics: OTEL_INSTRUMENTATION_METRICS_DURATION_UNIT("ms" or "s")
+// your self-diagnostics endpoint: OTEL_INSTRUMENTATION_DIAGNOSTICS_ADDR, e.g. "localhost:55679", pages are served under /debug/otel
+// your instrumentations: OTEL_INSTRUMENTATION_COMMON_DEFAULT_ENABLED and OTEL_INSTRUMENTATION_<NAME>_ENABLED, e.g. OTEL_INSTRUMENTATION_GIN_ENABLED=false
+// your http filter: OTEL_INSTRUMENTATION_HTTP_{INCLUDED,EXCLUDED}_PATHS OTEL_INSTRUMENTATION_HTTP_EXCLUDED_METHODS OTEL_INSTRUMENTATION_HTTP_EXCLUDED_USER_AGENTS, comma-separated globs, the included paths only apply to the servers
+// your captured http headers: OTEL_INSTRUMENTATION_HTTP_CAPTURE_HEADERS_{SERVER,CLIENT}_{REQUEST,RESPONSE}, comma-separated header names
+// your captured http bodies: OTEL_INSTRUMENTATION_HTTP_CAPTURE_BODY=true OTEL_INSTRUMENTATION_HTTP_CAPTURE_BODY_{MAX_SIZE,CONTENT_TYPES,REDACT_PATHS}
+// your captured gen_ai prompts and completions: OTEL_INSTRUMENTATION_GENAI_CAPTURE_MESSAGE_CONTENT=true OTEL_INSTRUMENTATION_GENAI_CAPTURE_MESSAGE_CONTENT_MAX_LENGTH
+// your db statements: OTEL_INSTRUMENTATION_DB_STATEMENT_SANITIZER_ENABLED(default true) OTEL_INSTRUMENTATION_DB_CAPTURE_PARAMETERS(default false)
+// your grpc message events: OTEL_INSTRUMENTATION_GRPC_{RECEIVED,SENT}_MESSAGE_EVENT_ENABLED=true
+// your baggage attributes: OTEL_INSTRUMENTATION_BAGGAGE_ATTRIBUTE_{KEYS,PREFIXES}, comma-separated, OTEL_INSTRUMENTATION_BAGGAGE_METRIC_ATTRIBUTES_ENABLED=true
+// your pprof labels: OTEL_INSTRUMENTATION_PPROF_LABELS_ENABLED=true, the cpu profiles of long local root spans: OTEL_INSTRUMENTATION_PPROF_CPU_PROFILE_{DIR,WINDOW} OTEL_INSTRUMENTATION_PPROF_LONG_SPAN_THRESHOLD (the collector holds the cpu profile exclusively, /debug/pprof/profile and pprof.StartCPUProfile fail while it is on)
+// your span metrics: OTEL_INSTRUMENTATION_SPAN_METRICS_ENABLED=true OTEL_INSTRUMENTATION_SPAN_METRICS_MAX_SERIES(default 1000)
+// your runtime switches: OTEL_INSTRUMENTATION_DYNAMIC_CONFIG_FILE, a yaml file polled for instrumentation and sampler changes
+// your declarative configuration file: OTEL_CONFIG_FILE, the sdk variables above are ignored when it is set
 const exec_name = "otel"
 const report_protocol = "OTEL_EXPORTER_OTLP_PROTOCOL"
 const trace_report_protocol = "OTEL_EXPORTER_OTLP_TRACES_PROTOCOL"
+const metrics_report_protocol = "OTEL_EXPORTER_OTLP_METRICS_PROTOCOL"
 const metrics_exporter = "OTEL_METRICS_EXPORTER"
 const trace_exporter = "OTEL_TRACES_EXPORTER"
 const prometheus_exporter_port = "OTEL_EXPORTER_PROMETHEUS_PORT"
 const default_prometheus_exporter_port = "9464"
+const metrics_duration_unit = "OTEL_INSTRUMENTATION_METRICS_DURATION_UNIT"
+const diagnostics_addr = "OTEL_INSTRUMENTATION_DIAGNOSTICS_ADDR"
+
+const (
+	protocol_grpc          = "grpc"
+	protocol_http_protobuf = "http/protobuf"
+	protocol_http_json     = "http/json"
+)
 
 var (
-	metricExporter     metric.Exporter
-	spanExporter       trace.SpanExporter
-	traceProvider      *trace.TracerProvider
-	metricsProvider    otelmetric.MeterProvider
-	batchSpanProcessor trace.SpanProcessor
+	metricExporters     []metric.Exporter
+	spanExporters       []trace.SpanExporter
+	traceProvider       *trace.TracerProvider
+	metricsProvider     otelmetric.MeterProvider
+	batchSpanProcessors []trace.SpanProcessor
+	cpuProfiler         *profiling.CPUProfiler
 )
 
 func init() {
@@ -93,100 +132,359 @@ func init() {
 	if err = initOpenTelemetry(ctx); err != nil {
 		log.Fatalf("%s: %v", "Failed to initialize opentelemetry resource", err)
 	}
+	startCPUProfiler()
 }
 
-func newSpanProcessor(ctx context.Context) trace.SpanProcessor {
+// startCPUProfiler collects the cpu profiles in process when
+// OTEL_INSTRUMENTATION_PPROF_CPU_PROFILE_DIR is set, their ids are added to
+// the long local root spans.
+func startCPUProfiler() {
+	p := profiling.NewCPUProfilerFromEnv()
+	if p == nil || testaccess.IsInTest() {
+		return
+	}
+	if err := p.Start(); err != nil {
+		log.Printf("%s: %v", "Failed to start the cpu profiler", err)
+		return
+	}
+	cpuProfiler = p
+	instrumenter.SetLocalRootSpanProfiler(p)
+}
+
+// getReportProtocol resolves the otlp protocol of a signal, the signal
+// specific variable takes precedence over the general one.
+func getReportProtocol(signalProtocol string) string {
+	protocol := strings.TrimSpace(os.Getenv(signalProtocol))
+	if protocol == "" {
+		protocol = strings.TrimSpace(os.Getenv(report_protocol))
+	}
+	switch protocol {
+	case protocol_grpc, protocol_http_json, protocol_http_protobuf:
+		return protocol
+	case "":
+		return protocol_http_protobuf
+	default:
+		log.Printf("unsupported otlp protocol %s, fall back to %s", protocol, protocol_http_protobuf)
+		return protocol_http_protobuf
+	}
+}
+
+// getExporterNames parses the comma-separated exporter list, "otlp" is used
+// when nothing is configured.
+func getExporterNames(env string) []string {
+	value := os.Getenv(env)
+	names := make([]string, 0, 2)
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(value, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		names = append(names, "otlp")
+	}
+	return names
+}
+
+func newSpanExporter(ctx context.Context, name string) (trace.SpanExporter, error) {
+	switch name {
+	case "console":
+		return stdouttrace.New()
+	case "zipkin":
+		return zipkin.New("")
+	case "file":
+		return exporter.NewFileSpanExporter(ctx, exporter.FileConfigFromEnv(exporter.SignalTraces))
+	case "otlp":
+		switch getReportProtocol(trace_report_protocol) {
+		case protocol_grpc:
+			return otlptrace.New(ctx, otlptracegrpc.NewClient())
+		case protocol_http_json:
+			return otlptrace.New(ctx, exporter.NewTraceHttpJsonClient(exporter.HttpJsonConfigFromEnv(exporter.SignalTraces)))
+		default:
+			return otlptrace.New(ctx, otlptracehttp.NewClient())
+		}
+	default:
+		return nil, fmt.Errorf("unsupported traces exporter %s", name)
+	}
+}
+
+func newSpanProcessors(ctx context.Context) []trace.SpanProcessor {
 	if testaccess.IsInTest() {
 		traceExporter := testaccess.GetSpanExporter()
 		// in test, we just send the span immediately
 		simpleProcessor := trace.NewSimpleSpanProcessor(traceExporter)
-		return simpleProcessor
-	} else {
+		return []trace.SpanProcessor{simpleProcessor}
+	}
+	for _, name := range getExporterNames(trace_exporter) {
+		var spanExporter trace.SpanExporter
 		var err error
-		if os.Getenv(trace_exporter) == "none" {
+		if name == "none" {
 			spanExporter = tracetest.NewNoopExporter()
-		} else if os.Getenv(trace_exporter) == "console" {
-			spanExporter, err = stdouttrace.New()
-		} else if os.Getenv(trace_exporter) == "zipkin" {
-			spanExporter, err = zipkin.New("")
 		} else {
-			if os.Getenv(report_protocol) == "grpc" || os.Getenv(trace_report_protocol) == "grpc" {
-				spanExporter, err = otlptrace.New(ctx, otlptracegrpc.NewClient())
-			} else {
-				spanExporter, err = otlptrace.New(ctx, otlptracehttp.NewClient())
-			}
+			spanExporter, err = newSpanExporter(ctx, name)
 		}
 		if err != nil {
 			log.Fatalf("%s: %v", "Failed to create the OpenTelemetry trace exporter", err)
 		}
-		batchSpanProcessor = trace.NewBatchSpanProcessor(spanExporter)
-		return batchSpanProcessor
+		if diagnosticsEnabled() {
+			spanExporter = diagnostics.WrapSpanExporter(name, spanExporter)
+		}
+		spanExporters = append(spanExporters, spanExporter)
+		batchSpanProcessors = append(batchSpanProcessors, trace.NewBatchSpanProcessor(spanExporter))
 	}
+	return batchSpanProcessors
 }
 
 func initOpenTelemetry(ctx context.Context) error {
+	if path := os.Getenv(config.ConfigFileEnv); path != "" && !testaccess.IsInTest() {
+		return initFromConfigFile(ctx, path)
+	}
 
-	batchSpanProcessor = newSpanProcessor(ctx)
+	spanProcessors := newSpanProcessors(ctx)
 
-	if batchSpanProcessor != nil {
-		traceProvider = trace.NewTracerProvider(
-			trace.WithSpanProcessor(batchSpanProcessor))
-	} else {
-		traceProvider = trace.NewTracerProvider()
+	opts := make([]trace.TracerProviderOption, 0, len(spanProcessors)+3)
+	opts = append(opts, baggageSpanProcessors()...)
+	opts = append(opts, diagnosticsSpanProcessors()...)
+	for _, spanProcessor := range spanProcessors {
+		opts = append(opts, trace.WithSpanProcessor(spanProcessor))
 	}
+	opts = append(opts, startDynamicConfig(ctx, nil, nil)...)
+	traceProvider = trace.NewTracerProvider(opts...)
 
 	otel.SetTracerProvider(traceProvider)
 	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
-	return initMetrics()
+	if err := initMetrics(); err != nil {
+		return err
+	}
+	registerSpanMetrics()
+	if diagnosticsEnabled() {
+		go diagnostics.Serve(os.Getenv(diagnostics_addr))
+	}
+	return nil
+}
+
+func diagnosticsEnabled() bool {
+	return os.Getenv(diagnostics_addr) != "" && !testaccess.IsInTest()
+}
+
+// diagnosticsSpanProcessors records the recently ended spans for the
+// self-diagnostics endpoint, it goes before the batch processors so that the
+// pending spans are not underestimated.
+func diagnosticsSpanProcessors() []trace.TracerProviderOption {
+	if !diagnosticsEnabled() {
+		return nil
+	}
+	return []trace.TracerProviderOption{trace.WithSpanProcessor(diagnostics.NewSpanRecorder(0))}
+}
+
+// baggageSpanProcessors copies the selected baggage members into the span
+// attributes, it goes first so that the other processors see them.
+func baggageSpanProcessors() []trace.TracerProviderOption {
+	selector := baggage.NewSelectorFromEnv()
+	if selector == nil {
+		return nil
+	}
+	return []trace.TracerProviderOption{trace.WithSpanProcessor(baggage.NewSpanProcessor(selector))}
+}
+
+// registerSpanMetrics derives the request count, error count and duration
+// metrics from every ended span, the processor is added once the meter is set
+// up so that its instruments are created only once.
+func registerSpanMetrics() {
+	p, err := spanmetrics.NewSpanProcessorFromEnv(meter.GetMeter())
+	if err != nil {
+		log.Printf("failed to create the span metrics: %v", err)
+		return
+	}
+	if p != nil {
+		traceProvider.RegisterSpanProcessor(p)
+	}
+}
+
+// startDynamicConfig watches OTEL_INSTRUMENTATION_DYNAMIC_CONFIG_FILE, the
+// returned option installs the sampler whose ratio can be switched at runtime.
+// base and settings are the sampler and the settings configured at startup.
+func startDynamicConfig(ctx context.Context, base trace.Sampler, settings *dynamic.Settings) []trace.TracerProviderOption {
+	path := os.Getenv(dynamic.ConfigFileEnv)
+	if path == "" || testaccess.IsInTest() {
+		return nil
+	}
+	dynamic.SetBase(settings)
+	sampler := dynamic.DynamicSampler()
+	sampler.SetBase(base)
+	dynamic.Start(ctx, dynamic.NewFileProvider(path))
+	return []trace.TracerProviderOption{trace.WithSampler(sampler)}
+}
+
+// initFromConfigFile builds the providers from the declarative configuration
+// file instead of the environment variables.
+func initFromConfigFile(ctx context.Context, path string) error {
+	cfg, err := config.ParseFile(path)
+	if err != nil {
+		return err
+	}
+	if cfg.Instrumentation != nil {
+		instrumenter.ConfigureInstrumentEnablers(cfg.Instrumentation.DefaultEnabled, cfg.Instrumentation.Overrides())
+		if cfg.Instrumentation.HttpFilter != nil {
+			filter, err := cfg.Instrumentation.HttpFilter.NewFilter()
+			if err != nil {
+				return err
+			}
+			instutils.SetHttpFilter(filter)
+		}
+	}
+	var opts []config.Option
+	if diagnosticsEnabled() {
+		opts = append(opts,
+			config.WithSpanExporterWrapper(diagnostics.WrapSpanExporter),
+			config.WithMetricExporterWrapper(diagnostics.WrapMetricExporter))
+		go diagnostics.Serve(os.Getenv(diagnostics_addr))
+	}
+	sdk, err := config.NewSDK(ctx, cfg, opts...)
+	if err != nil {
+		return err
+	}
+	if sdk.Disabled {
+		// the global tracer provider is a noop one until it is set
+		metricsProvider = noop.NewMeterProvider()
+		return registerMetrics(metricsProvider)
+	}
+	spanExporters = append(spanExporters, sdk.SpanExporters...)
+	batchSpanProcessors = append(batchSpanProcessors, sdk.SpanProcessors...)
+	tpOpts := append(baggageSpanProcessors(), diagnosticsSpanProcessors()...)
+	tpOpts = append(tpOpts, sdk.TracerProviderOptions()...)
+	tpOpts = append(tpOpts, startDynamicConfig(ctx, sdk.Sampler, &dynamic.Settings{Instrumentation: cfg.Instrumentation})...)
+	traceProvider = trace.NewTracerProvider(tpOpts...)
+	otel.SetTracerProvider(traceProvider)
+	if sdk.Propagator != nil {
+		otel.SetTextMapPropagator(sdk.Propagator)
+	} else {
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	}
+
+	metricExporters = append(metricExporters, sdk.MetricExporters...)
+	if len(sdk.MetricReaders) == 0 {
+		metricsProvider = noop.NewMeterProvider()
+	} else {
+		metricsProvider = metric.NewMeterProvider(sdk.MeterProviderOptions()...)
+	}
+	if sdk.PrometheusAddr != "" {
+		go serveMetrics(sdk.PrometheusAddr)
+	}
+	if err := registerMetrics(metricsProvider); err != nil {
+		return err
+	}
+	registerSpanMetrics()
+	return nil
+}
+
+func newMetricReader(ctx context.Context, name string) (metric.Reader, error) {
+	var metricExporter metric.Exporter
+	var err error
+	switch name {
+	case "console":
+		metricExporter, err = stdoutmetric.New()
+	case "file":
+		metricExporter, err = exporter.NewFileMetricExporter(exporter.FileConfigFromEnv(exporter.SignalMetrics))
+	case "prometheus":
+		promExporter, err := prometheus.New()
+		if err != nil {
+			return nil, err
+		}
+		port := os.Getenv(prometheus_exporter_port)
+		if port == "" {
+			port = default_prometheus_exporter_port
+		}
+		go serveMetrics(fmt.Sprintf(":%s", port))
+		return promExporter, nil
+	case "otlp":
+		switch getReportProtocol(metrics_report_protocol) {
+		case protocol_grpc:
+			metricExporter, err = otlpmetricgrpc.New(ctx)
+		case protocol_http_json:
+			metricExporter = exporter.NewMetricHttpJsonExporter(exporter.HttpJsonConfigFromEnv(exporter.SignalMetrics))
+		default:
+			metricExporter, err = otlpmetrichttp.New(ctx)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported metrics exporter %s", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if diagnosticsEnabled() {
+		metricExporter = diagnostics.WrapMetricExporter(name, metricExporter)
+	}
+	metricExporters = append(metricExporters, metricExporter)
+	return metric.NewPeriodicReader(metricExporter), nil
+}
+
+// newMetricViews loads the views of the built-in metrics, views are applied
+// when the instruments are created so they must be ready before the
+// MeterProvider is built.
+func newMetricViews() []metric.View {
+	path := os.Getenv(config.MetricViewsFileEnv)
+	if path == "" {
+		return nil
+	}
+	views, err := config.ParseViewsFile(path)
+	if err != nil {
+		log.Fatalf("Failed to load metric views: %v", err)
+	}
+	return views
 }
 
 func initMetrics() error {
 	ctx := context.Background()
-	// TODO: abstract the if-else
-	var err error
+	views := newMetricViews()
 	if testaccess.IsInTest() {
 		metricsProvider = metric.NewMeterProvider(
 			metric.WithReader(testaccess.ManualReader),
+			metric.WithView(views...),
 		)
 	} else {
-		if os.Getenv(metrics_exporter) == "none" {
-			metricsProvider = noop.NewMeterProvider()
-		} else if os.Getenv(metrics_exporter) == "console" {
-			metricExporter, err = stdoutmetric.New()
-			metricsProvider = metric.NewMeterProvider(
-				metric.WithReader(metric.NewPeriodicReader(metricExporter)),
-			)
-		} else if os.Getenv(metrics_exporter) == "prometheus" {
-			promExporter, err := prometheus.New()
+		opts := make([]metric.Option, 0, 2)
+		for _, name := range getExporterNames(metrics_exporter) {
+			if name == "none" {
+				continue
+			}
+			reader, err := newMetricReader(ctx, name)
 			if err != nil {
-				log.Fatalf("Failed to create prometheus metric exporter: %v", err)
+				log.Fatalf("Failed to create metric exporter: %v", err)
 			}
-			metricsProvider = metric.NewMeterProvider(
-				metric.WithReader(promExporter),
-			)
-			go serveMetrics()
+			opts = append(opts, metric.WithReader(reader))
+		}
+		if len(opts) == 0 {
+			metricsProvider = noop.NewMeterProvider()
 		} else {
-			if os.Getenv(report_protocol) == "grpc" || os.Getenv(trace_report_protocol) == "grpc" {
-				metricExporter, err = otlpmetricgrpc.New(ctx)
-				metricsProvider = metric.NewMeterProvider(
-					metric.WithReader(metric.NewPeriodicReader(metricExporter)),
-				)
-			} else {
-				metricExporter, err = otlpmetrichttp.New(ctx)
-				metricsProvider = metric.NewMeterProvider(
-					metric.WithReader(metric.NewPeriodicReader(metricExporter)),
-				)
-			}
+			opts = append(opts, metric.WithView(views...))
+			metricsProvider = metric.NewMeterProvider(opts...)
 		}
 	}
-	if err != nil {
-		log.Fatalf("Failed to create metric exporter: %v", err)
-	}
-	if metricsProvider == nil {
+	return registerMetrics(metricsProvider)
+}
+
+// registerMetrics sets the global MeterProvider and creates the built-in
+// instruments on it.
+func registerMetrics(mp otelmetric.MeterProvider) error {
+	if mp == nil {
 		return errors.New("No MeterProvider is provided")
 	}
-	otel.SetMeterProvider(metricsProvider)
-	m := metricsProvider.Meter("opentelemetry-global-meter")
+	otel.SetMeterProvider(mp)
+	unit := os.Getenv(metrics_duration_unit)
+	switch unit {
+	case "", utils.DurationUnitMilliseconds, utils.DurationUnitSeconds:
+		utils.SetDurationUnit(unit)
+	default:
+		log.Printf("unsupported duration unit %s, fall back to %s", unit, utils.DurationUnitMilliseconds)
+	}
+	m := mp.Meter("opentelemetry-global-meter")
+	if baggage.MetricsEnabled() {
+		m = baggage.WrapMeter(m, baggage.NewSelectorFromEnv())
+	}
 	meter.SetMeter(m)
 	// init http metrics
 	http.InitHttpMetrics(m)
@@ -194,20 +492,20 @@ func initMetrics() error {
 	rpc.InitRpcMetrics(m)
 	// init db metrics
 	db.InitDbMetrics(m)
+	// init messaging metrics
+	message.InitMessageMetrics(m)
+	// init gen_ai metrics
+	ai.InitAIMetrics(m)
 	// nacos experimental metrics
 	experimental.InitNacosExperimentalMetrics(m)
 	// DefaultMinimumReadMemStatsInterval is 15 second
-	return otelruntime.Start(otelruntime.WithMeterProvider(metricsProvider))
+	return otelruntime.Start(otelruntime.WithMeterProvider(mp))
 }
 
-func serveMetrics() {
+func serveMetrics(addr string) {
 	http2.Handle("/metrics", promhttp.Handler())
-	port := os.Getenv(prometheus_exporter_port)
-	if port == "" {
-		port = default_prometheus_exporter_port
-	}
-	log.Printf("serving serveMetrics at localhost:%s/metrics", port)
-	err := http2.ListenAndServe(fmt.Sprintf(":%s", port), nil)
+	log.Printf("serving serveMetrics at %s/metrics", addr)
+	err := http2.ListenAndServe(addr, nil)
 	if err != nil {
 		fmt.Printf("error serving serveMetrics: %v", err)
 		return
@@ -215,30 +513,41 @@ func serveMetrics() {
 }
 
 func gracefullyShutdown(ctx context.Context) {
+	if cpuProfiler != nil {
+		cpuProfiler.Stop()
+	}
 	if metricsProvider != nil {
 		mp, ok := metricsProvider.(*metric.MeterProvider)
 		if ok {
+			// flush the pending metrics before shutting down, otherwise
+			// exporters such as the file exporter may lose the last batch
+			if err := mp.ForceFlush(ctx); err != nil {
+				log.Printf("%s: %v", "Failed to flush the OpenTelemetry metric provider", err)
+			}
 			if err := mp.Shutdown(ctx); err != nil {
 				log.Printf("%s: %v", "Failed to shutdown the OpenTelemetry metric provider", err)
 			}
 		}
 	}
 	if traceProvider != nil {
+		if err := traceProvider.ForceFlush(ctx); err != nil {
+			log.Printf("%s: %v", "Failed to flush the OpenTelemetry trace provider", err)
+		}
 		if err := traceProvider.Shutdown(ctx); err != nil {
 			log.Printf("%s: %v", "Failed to shutdown the OpenTelemetry trace provider", err)
 		}
 	}
-	if spanExporter != nil {
+	for _, spanExporter := range spanExporters {
 		if err := spanExporter.Shutdown(ctx); err != nil {
 			log.Printf("%s: %v", "Failed to shutdown the OpenTelemetry span exporter", err)
 		}
 	}
-	if metricExporter != nil {
+	for _, metricExporter := range metricExporters {
 		if err := metricExporter.Shutdown(ctx); err != nil {
 			log.Printf("%s: %v", "Failed to shutdown the OpenTelemetry metric exporter", err)
 		}
 	}
-	if batchSpanProcessor != nil {
+	for _, batchSpanProcessor := range batchSpanProcessors {
 		if err := batchSpanProcessor.Shutdown(ctx); err != nil {
 			log.Printf("%s: %v", "Failed to shutdown the OpenTelemetry batch span processor", err)
 		}
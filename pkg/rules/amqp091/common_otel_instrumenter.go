@@ -116,6 +116,14 @@ func BuildRabbitMQConsumeOtelInstrumenter() *instrumenter.PropagatingFromUpstrea
 	return builder.Init().SetSpanNameExtractor(&message.MessageSpanNameExtractor[RabbitRequest, any]{Getter: RabbitMQGetter{}, OperationName: message.RECEIVE}).
 		SetSpanKindExtractor(&instrumenter.AlwaysConsumerExtractor[RabbitRequest]{}).
 		AddAttributesExtractor(&message.MessageAttrsExtractor[RabbitRequest, any, RabbitMQGetter]{Operation: message.RECEIVE}).
+		AddOperationListeners(message.MessageMetrics("amqp091.consumer", message.RECEIVE)).
+		// link the publisher context of the delivery when the consumer span
+		// is not parented to it
+		SetSpanLinksExtractor(&instrumenter.PropagatorSpanLinksExtractor[RabbitRequest]{
+			CarriersGetter: func(n RabbitRequest) []propagation.TextMapCarrier {
+				return []propagation.TextMapCarrier{&carrierGetter{req: n}}
+			},
+		}).
 		SetInstrumentationScope(instrumentation.Scope{
 			Name:    utils.AMQP091_SCOPE_NAME,
 			Version: version.Tag,
@@ -133,6 +141,7 @@ func BuildRabbitMQPublishOtelInstrumenter() *instrumenter.PropagatingToDownstrea
 			Version: version.Tag,
 		}).
 		AddAttributesExtractor(&message.MessageAttrsExtractor[RabbitRequest, any, RabbitMQGetter]{Operation: message.PUBLISH}).
+		AddOperationListeners(message.MessageMetrics("amqp091.producer", message.PUBLISH)).
 		BuildPropagatingToDownstreamInstrumenter(func(n RabbitRequest) propagation.TextMapCarrier {
 			return &carrierGetter{req: n}
 		}, otel.GetTextMapPropagator())
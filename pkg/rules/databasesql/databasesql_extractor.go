@@ -17,6 +17,7 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api-semconv/instrumenter/db"
 	"github.com/xwb1989/sqlparser"
 )
 
@@ -45,6 +46,20 @@ func getCollection(sql string) string {
 	return extractCollection(sql)
 }
 
+func getSanitizedStatement(sql string, system string) string {
+	meta, found := sqlCache.Get(sql)
+	if found && meta.sanitized != "" {
+		return meta.sanitized
+	}
+	sanitized := db.SanitizeStatement(system, sql)
+	if found {
+		updatedMeta := meta
+		updatedMeta.sanitized = sanitized
+		sqlCache.Add(sql, updatedMeta)
+	}
+	return sanitized
+}
+
 func getParams(sql string) []any {
 	meta, found := sqlCache.Get(sql)
 	if found && len(meta.params) > 0 {
@@ -25,22 +25,24 @@ import (
 type databaseSqlAttrsGetter struct {
 }
 
+var _ db.DbSanitizedStatementGetter[databaseSqlRequest] = databaseSqlAttrsGetter{}
+var _ db.DbServerPortGetter[databaseSqlRequest] = databaseSqlAttrsGetter{}
+
 func (d databaseSqlAttrsGetter) GetSystem(request databaseSqlRequest) string {
-	switch request.driverName {
-	case "mysql":
-		return "mysql"
-	case "postgres":
-		fallthrough
-	case "postgresql":
-		return "postgresql"
-	}
-	return "database"
+	return getDSNInfo(request.driverName, request.dsn).System
 }
 
 func (d databaseSqlAttrsGetter) GetServerAddress(request databaseSqlRequest) string {
+	if host := getDSNInfo(request.driverName, request.dsn).Host; host != "" {
+		return host
+	}
 	return request.endpoint
 }
 
+func (d databaseSqlAttrsGetter) GetServerPort(request databaseSqlRequest) int {
+	return getDSNInfo(request.driverName, request.dsn).Port
+}
+
 func (d databaseSqlAttrsGetter) GetStatement(request databaseSqlRequest) string {
 	// Fetch metadata along with the SQL
 	// Retrieve db collection only, not the params which is an experimental feature that will introduce some overhead.
@@ -48,6 +50,12 @@ func (d databaseSqlAttrsGetter) GetStatement(request databaseSqlRequest) string
 	return request.sql
 }
 
+// GetSanitizedStatement caches the sanitized statement along with the metadata
+func (d databaseSqlAttrsGetter) GetSanitizedStatement(request databaseSqlRequest) string {
+	extractSQLMetadata(request)
+	return getSanitizedStatement(request.sql, d.GetSystem(request))
+}
+
 func (d databaseSqlAttrsGetter) GetOperation(request databaseSqlRequest) string {
 	return request.opType
 }
@@ -67,7 +75,7 @@ func (d databaseSqlAttrsGetter) GetParameters(request databaseSqlRequest) []any
 }
 
 func (d databaseSqlAttrsGetter) GetDbNamespace(request databaseSqlRequest) string {
-	return ""
+	return getDSNInfo(request.driverName, request.dsn).Database
 }
 
 func (d databaseSqlAttrsGetter) GetBatchSize(request databaseSqlRequest) int {
@@ -15,52 +15,31 @@
 package databasesql
 
 import (
-	"errors"
-	"fmt"
-	nurl "net/url"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api-semconv/instrumenter/db"
+	lru "github.com/hashicorp/golang-lru/v2"
 )
 
-func parseDSN(driverName, dsn string) (addr string, err error) {
-	// TODO: need a more delegate DFA
-	switch driverName {
-	case "mysql":
-		return parseMySQL(dsn)
-	case "postgres":
-		fallthrough
-	case "postgresql":
-		return parsePostgres(dsn)
-	}
-
-	return "", errors.New("invalid DSN")
-}
+const dsnCacheSize = 64
 
-func parsePostgres(url string) (addr string, err error) {
-	u, err := nurl.Parse(url)
-	if err != nil {
-		return "", err
-	}
+// the dsn is parsed for every request, there are only a few of them
+var dsnCache, _ = lru.New[string, db.DsnInfo](dsnCacheSize)
 
-	if u.Scheme != "postgres" && u.Scheme != "postgresql" {
-		return "", fmt.Errorf("invalid connection protocol: %s", u.Scheme)
+// getDSNInfo parses the dsn once, the systems of the dsns that can not be
+// parsed are still resolved by the driver name.
+func getDSNInfo(driverName, dsn string) db.DsnInfo {
+	key := driverName + "\x00" + dsn
+	if info, ok := dsnCache.Get(key); ok {
+		return info
 	}
-
-	return u.Host + ":" + u.Port(), nil
+	info, _ := db.ParseDsn(driverName, dsn)
+	dsnCache.Add(key, info)
+	return info
 }
 
-func parseMySQL(dsn string) (addr string, err error) {
-	n := len(dsn)
-	i, j := -1, -1
-	for k := 0; k < n; k++ {
-		if dsn[k] == '(' {
-			i = k
-		}
-		if dsn[k] == ')' {
-			j = k
-			break
-		}
-	}
-	if i >= 0 && j > i {
-		return dsn[i+1 : j], nil
+func parseDSN(driverName, dsn string) (addr string, err error) {
+	info, err := db.ParseDsn(driverName, dsn)
+	if err != nil {
+		return "", err
 	}
-	return "", errors.New("invalid MySQL DSN")
+	return info.Endpoint(), nil
 }
@@ -0,0 +1,170 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databasesql
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+	_ "unsafe"
+
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/api"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api-semconv/instrumenter/db"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// database/sql keeps 2 idle connections unless SetMaxIdleConns is called
+const defaultMaxIdleConns = 2
+
+// dbPool keeps what sql.DBStats does not tell about the pool
+type dbPool struct {
+	reg     metric.Registration
+	times   *db.DbPoolTimes
+	maxIdle atomic.Int64
+}
+
+// acquiredConn is kept from (*DB).conn to (*DB).putConn to record the time
+// the connection is used
+type acquiredConn struct {
+	ctx      context.Context
+	times    *db.DbPoolTimes
+	acquired time.Time
+}
+
+// acquiredConns maps the *driverConn in use to their acquiredConn
+var acquiredConns sync.Map
+
+// poolName is endpoint/database, the pools opened with the same dsn are
+// numbered to keep the name unique.
+func poolName(info db.DsnInfo) string {
+	name := info.Endpoint()
+	if info.Database != "" {
+		name += "/" + info.Database
+	}
+	return db.DbPoolName(name)
+}
+
+func registerPool(sqlDb *sql.DB) {
+	info := getDSNInfo(sqlDb.DriverName, sqlDb.DSN)
+	name := poolName(info)
+	pool := &dbPool{}
+	pool.maxIdle.Store(defaultMaxIdleConns)
+	reg, err := db.RegisterDbPoolMetrics(name, info.Host, func() db.DbPoolStats {
+		stats := sqlDb.Stats()
+		idleMax := pool.maxIdle.Load()
+		// the idle connections are limited by the open ones as well
+		if maxOpen := int64(stats.MaxOpenConnections); maxOpen > 0 && idleMax > maxOpen {
+			idleMax = maxOpen
+		}
+		return db.DbPoolStats{
+			Idle:    int64(stats.Idle),
+			Used:    int64(stats.InUse),
+			Max:     int64(stats.MaxOpenConnections),
+			IdleMax: idleMax,
+			// database/sql does not time out, it waits until the context is done
+			Timeouts: -1,
+			WaitTime: stats.WaitDuration,
+		}
+	})
+	if err != nil {
+		log.Printf("failed to register the connection pool metrics: %v", err)
+		return
+	}
+	pool.reg = reg
+	if pool.times, err = db.NewDbPoolTimes(name, info.Host); err != nil {
+		log.Printf("failed to create the connection wait and use time metrics: %v", err)
+	}
+	sqlDb.OtelPool = pool
+}
+
+type connCallData struct {
+	ctx   context.Context
+	times *db.DbPoolTimes
+	start time.Time
+}
+
+//go:linkname beforeDbConnInstrumentation database/sql.beforeDbConnInstrumentation
+func beforeDbConnInstrumentation(call api.CallContext, sqlDb *sql.DB, ctx context.Context, strategy interface{}) {
+	if !dbSqlEnabler.Enable() {
+		return
+	}
+	if sqlDb == nil {
+		return
+	}
+	pool, ok := sqlDb.OtelPool.(*dbPool)
+	if !ok || pool.times == nil {
+		return
+	}
+	call.SetData(&connCallData{ctx: ctx, times: pool.times, start: time.Now()})
+}
+
+//go:linkname afterDbConnInstrumentation database/sql.afterDbConnInstrumentation
+func afterDbConnInstrumentation(call api.CallContext, dc interface{}, err error) {
+	if !dbSqlEnabler.Enable() {
+		return
+	}
+	data, ok := call.GetData().(*connCallData)
+	if !ok || err != nil || dc == nil {
+		return
+	}
+	now := time.Now()
+	data.times.RecordWaitTime(data.ctx, now.Sub(data.start))
+	acquiredConns.Store(dc, &acquiredConn{ctx: data.ctx, times: data.times, acquired: now})
+}
+
+//go:linkname beforePutConnInstrumentation database/sql.beforePutConnInstrumentation
+func beforePutConnInstrumentation(call api.CallContext, sqlDb *sql.DB, dc interface{}, err error, resetSession bool) {
+	// the connections acquired before the instrumentation is disabled are
+	// still released
+	if dc == nil {
+		return
+	}
+	if v, ok := acquiredConns.LoadAndDelete(dc); ok {
+		conn := v.(*acquiredConn)
+		conn.times.RecordUseTime(conn.ctx, time.Since(conn.acquired))
+	}
+}
+
+//go:linkname beforeSetMaxIdleConnsInstrumentation database/sql.beforeSetMaxIdleConnsInstrumentation
+func beforeSetMaxIdleConnsInstrumentation(call api.CallContext, sqlDb *sql.DB, n int) {
+	if !dbSqlEnabler.Enable() {
+		return
+	}
+	if sqlDb == nil {
+		return
+	}
+	if pool, ok := sqlDb.OtelPool.(*dbPool); ok {
+		pool.maxIdle.Store(int64(max(n, 0)))
+	}
+}
+
+//go:linkname beforeCloseInstrumentation database/sql.beforeCloseInstrumentation
+func beforeCloseInstrumentation(call api.CallContext, sqlDb *sql.DB) {
+	if !dbSqlEnabler.Enable() {
+		return
+	}
+	if sqlDb == nil {
+		return
+	}
+	if pool, ok := sqlDb.OtelPool.(*dbPool); ok {
+		sqlDb.OtelPool = nil
+		if err := pool.reg.Unregister(); err != nil {
+			log.Printf("failed to unregister the connection pool metrics: %v", err)
+		}
+	}
+}
@@ -18,25 +18,17 @@ import (
 	"context"
 	"database/sql"
 	"log"
-	"os"
 	"strings"
 
 	_ "unsafe"
 
 	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/api"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/instrumenter"
 )
 
 var databaseSqlInstrumenter = BuildDatabaseSqlOtelInstrumenter()
 
-type dbSqlInnerEnabler struct {
-	enabled bool
-}
-
-func (d dbSqlInnerEnabler) Enable() bool {
-	return d.enabled
-}
-
-var dbSqlEnabler = dbSqlInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_DATABASESQL_ENABLED") != "false"}
+var dbSqlEnabler = instrumenter.RegisterInstrumentEnabler("databasesql", true)
 
 const (
 	cacheUpperBound = 1024
@@ -92,6 +84,7 @@ func afterOpenInstrumentation(call api.CallContext, db *sql.DB, err error) {
 	if ok {
 		db.DSN = dsn
 	}
+	registerPool(db)
 }
 
 //go:linkname beforePingContextInstrumentation database/sql.beforePingContextInstrumentation
@@ -24,6 +24,7 @@ type SQLMeta struct {
 	operation  string
 	collection string
 	params     []any
+	sanitized  string
 }
 
 type SQLMetaCache struct {
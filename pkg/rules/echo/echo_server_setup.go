@@ -15,23 +15,17 @@
 package echo
 
 import (
-	"os"
 	_ "unsafe"
 
 	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/api"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api-semconv/instrumenter/http"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/instrumenter"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/utils"
 	echo "github.com/labstack/echo/v4"
 	"go.opentelemetry.io/otel/sdk/trace"
 )
 
-type echoInnerEnabler struct {
-	enabled bool
-}
-
-func (e echoInnerEnabler) Enable() bool {
-	return e.enabled
-}
-
-var echoEnabler = echoInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_ECHO_ENABLED") != "false"}
+var echoEnabler = instrumenter.RegisterInstrumentEnabler("echo", true)
 
 func otelTraceMiddleware() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
@@ -39,6 +33,12 @@ func otelTraceMiddleware() echo.MiddlewareFunc {
 			if err = next(c); err != nil {
 				c.Error(err)
 			}
+			if r := c.Request(); r != nil && utils.FilterHttpRequest(r.Method, r.URL, r.UserAgent()) {
+				return
+			}
+			if c.Request() != nil && http.UpdateHttpRoute(c.Request().Context(), c.Path()) {
+				return
+			}
 			lcs := trace.LocalRootSpanFromGLS()
 			if lcs != nil && c.Path() != "" && c.Request() != nil && c.Request().URL != nil && (c.Request().URL.Path != c.Path()) {
 				lcs.SetName(c.Path())
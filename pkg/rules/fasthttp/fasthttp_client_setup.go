@@ -20,6 +20,7 @@ import (
 	_ "unsafe"
 
 	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/api"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/utils"
 	"github.com/valyala/fasthttp"
 )
 
@@ -39,11 +40,16 @@ func clientFastHttpOnEnter(call api.CallContext, c *fasthttp.HostClient, req *fa
 	if err != nil {
 		return
 	}
+	if utils.FilterHttpClientRequest(string(req.Header.Method()), u, string(req.Header.UserAgent())) {
+		return
+	}
 	request := fastHttpRequest{
-		method: string(req.Header.Method()),
-		url:    u,
-		isTls:  isTLS,
-		header: &req.Header,
+		method:   string(req.Header.Method()),
+		url:      u,
+		isTls:    isTLS,
+		header:   &req.Header,
+		body:     captureRequestBody(req),
+		bodySize: getRequestBodySize(req),
 	}
 	ctx := fastHttpClientInstrumenter.Start(context.Background(), request)
 	data := make(map[string]interface{}, 3)
@@ -58,12 +64,17 @@ func clientFastHttpOnExit(call api.CallContext, err error) {
 	if !fastHttpEnabler.Enable() {
 		return
 	}
-	data := call.GetData().(map[string]interface{})
+	data, ok := call.GetData().(map[string]interface{})
+	if !ok {
+		return
+	}
 	ctx := data["ctx"].(context.Context)
 	request := data["request"].(fastHttpRequest)
 	resp := data["response"].(*fasthttp.Response)
 	fastHttpClientInstrumenter.End(ctx, request, fastHttpResponse{
 		statusCode: resp.StatusCode(),
 		header:     &resp.Header,
+		body:       captureResponseBody(resp),
+		bodySize:   getResponseBodySize(resp),
 	}, err)
 }
@@ -17,18 +17,55 @@ package fasthttp
 import (
 	"net/url"
 
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api-semconv/instrumenter/http"
 	"github.com/valyala/fasthttp"
 )
 
 type fastHttpRequest struct {
-	method string
-	url    *url.URL
-	isTls  bool
-	port   int
-	header *fasthttp.RequestHeader
+	method   string
+	url      *url.URL
+	isTls    bool
+	port     int
+	header   *fasthttp.RequestHeader
+	body     *http.BodyBuffer
+	bodySize int64
 }
 
 type fastHttpResponse struct {
 	statusCode int
 	header     *fasthttp.ResponseHeader
+	body       *http.BodyBuffer
+	bodySize   int64
+}
+
+// the size of a streamed body is only known by its content length, which is
+// negative when unknown
+func getRequestBodySize(req *fasthttp.Request) int64 {
+	if req.IsBodyStream() {
+		return int64(req.Header.ContentLength())
+	}
+	return int64(len(req.Body()))
+}
+
+func getResponseBodySize(resp *fasthttp.Response) int64 {
+	if resp.IsBodyStream() {
+		return int64(resp.Header.ContentLength())
+	}
+	return int64(len(resp.Body()))
+}
+
+// captureRequestBody skips the streamed bodies, reading them here would
+// consume the stream.
+func captureRequestBody(req *fasthttp.Request) *http.BodyBuffer {
+	if !http.BodyCaptureEnabled() || req.IsBodyStream() {
+		return nil
+	}
+	return http.CaptureBody(string(req.Header.ContentType()), req.Body())
+}
+
+func captureResponseBody(resp *fasthttp.Response) *http.BodyBuffer {
+	if !http.BodyCaptureEnabled() || resp.IsBodyStream() {
+		return nil
+	}
+	return http.CaptureBody(string(resp.Header.ContentType()), resp.Body())
 }
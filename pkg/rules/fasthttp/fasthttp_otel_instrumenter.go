@@ -17,7 +17,6 @@ import (
 	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/utils"
 	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/version"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
-	"os"
 	"strconv"
 
 	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api-semconv/instrumenter/http"
@@ -30,15 +29,7 @@ import (
 
 var emptyFastHttpResponse = fastHttpResponse{}
 
-type fastHttpInnerEnabler struct {
-	enabled bool
-}
-
-func (g fastHttpInnerEnabler) Enable() bool {
-	return g.enabled
-}
-
-var fastHttpEnabler = fastHttpInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_FASTHTTP_ENABLED") != "false"}
+var fastHttpEnabler = instrumenter.RegisterInstrumentEnabler("fasthttp", true)
 
 type fastHttpClientAttrsGetter struct {
 }
@@ -56,6 +47,18 @@ func (n fastHttpClientAttrsGetter) GetHttpRequestHeader(request fastHttpRequest,
 func (n fastHttpClientAttrsGetter) GetHttpResponseStatusCode(request fastHttpRequest, response fastHttpResponse, err error) int {
 	return response.statusCode
 }
+func (n fastHttpClientAttrsGetter) GetHttpRequestBody(request fastHttpRequest) *http.BodyBuffer {
+	return request.body
+}
+func (n fastHttpClientAttrsGetter) GetHttpResponseBody(request fastHttpRequest, response fastHttpResponse) *http.BodyBuffer {
+	return response.body
+}
+func (n fastHttpClientAttrsGetter) GetHttpRequestBodySize(request fastHttpRequest) int64 {
+	return request.bodySize
+}
+func (n fastHttpClientAttrsGetter) GetHttpResponseBodySize(request fastHttpRequest, response fastHttpResponse) int64 {
+	return response.bodySize
+}
 func (n fastHttpClientAttrsGetter) GetHttpResponseHeader(request fastHttpRequest, response fastHttpResponse, name string) []string {
 	all := make([]string, 0)
 	for _, header := range response.header.PeekAll(name) {
@@ -125,6 +128,18 @@ func (n fastHttpServerAttrsGetter) GetHttpRequestHeader(request fastHttpRequest,
 func (n fastHttpServerAttrsGetter) GetHttpResponseStatusCode(request fastHttpRequest, response fastHttpResponse, err error) int {
 	return response.statusCode
 }
+func (n fastHttpServerAttrsGetter) GetHttpRequestBody(request fastHttpRequest) *http.BodyBuffer {
+	return request.body
+}
+func (n fastHttpServerAttrsGetter) GetHttpResponseBody(request fastHttpRequest, response fastHttpResponse) *http.BodyBuffer {
+	return response.body
+}
+func (n fastHttpServerAttrsGetter) GetHttpRequestBodySize(request fastHttpRequest) int64 {
+	return request.bodySize
+}
+func (n fastHttpServerAttrsGetter) GetHttpResponseBodySize(request fastHttpRequest, response fastHttpResponse) int64 {
+	return response.bodySize
+}
 func (n fastHttpServerAttrsGetter) GetHttpResponseHeader(request fastHttpRequest, response fastHttpResponse, name string) []string {
 	all := make([]string, 0)
 	for _, header := range response.header.PeekAll(name) {
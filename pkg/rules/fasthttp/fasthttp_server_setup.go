@@ -20,6 +20,7 @@ import (
 	_ "unsafe"
 
 	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/api"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/utils"
 	"github.com/valyala/fasthttp"
 )
 
@@ -33,15 +34,22 @@ func newFastHttpServerDelegateHandler(handler fasthttp.RequestHandler) fasthttp.
 		if err != nil {
 			return
 		}
+		if utils.FilterHttpRequest(string(ctx.Method()), u, string(ctx.UserAgent())) {
+			return
+		}
 		request := fastHttpRequest{
-			method: string(ctx.Method()),
-			url:    u,
-			isTls:  ctx.IsTLS(),
-			header: &ctx.Request.Header,
+			method:   string(ctx.Method()),
+			url:      u,
+			isTls:    ctx.IsTLS(),
+			header:   &ctx.Request.Header,
+			body:     captureRequestBody(&ctx.Request),
+			bodySize: getRequestBodySize(&ctx.Request),
 		}
 		fastHttpServerInstrumenter.StartAndEnd(ctx, request, fastHttpResponse{
 			statusCode: ctx.Response.StatusCode(),
 			header:     &ctx.Response.Header,
+			body:       captureResponseBody(&ctx.Response),
+			bodySize:   getResponseBodySize(&ctx.Response),
 		}, ctx.Err(), startTime, time.Now())
 	}
 }
@@ -20,6 +20,7 @@ import (
 	_ "unsafe"
 
 	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/api"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/utils"
 	fiber "github.com/gofiber/fiber/v2"
 	"github.com/valyala/fasthttp"
 )
@@ -35,6 +36,9 @@ func fiberHttpOnEnterv2(call api.CallContext, app *fiber.App, ctx *fasthttp.Requ
 	if err != nil {
 		return
 	}
+	if utils.FilterHttpRequest(string(ctx.Method()), u, string(ctx.UserAgent())) {
+		return
+	}
 	request := &fiberv2Request{
 		method: string(ctx.Method()),
 		url:    u,
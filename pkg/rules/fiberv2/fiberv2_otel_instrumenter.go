@@ -14,7 +14,6 @@
 package fiberv2
 
 import (
-	"os"
 	"strconv"
 
 	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/utils"
@@ -31,15 +30,7 @@ import (
 
 var emptyFiberv2Response = fiberv2Response{}
 
-type fiberV2InnerEnabler struct {
-	enabled bool
-}
-
-func (g fiberV2InnerEnabler) Enable() bool {
-	return g.enabled
-}
-
-var fiberV2Enabler = fiberV2InnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_FIBERV2_ENABLED") != "false"}
+var fiberV2Enabler = instrumenter.RegisterInstrumentEnabler("fiberv2", true)
 
 type fiberv2ServerAttrsGetter struct {
 }
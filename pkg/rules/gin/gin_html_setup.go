@@ -18,6 +18,8 @@ import (
 	_ "unsafe"
 
 	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/api"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api-semconv/instrumenter/http"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/utils"
 	"go.opentelemetry.io/otel/sdk/trace"
 
 	"github.com/gin-gonic/gin"
@@ -31,6 +33,12 @@ func htmlOnEnter(call api.CallContext, c *gin.Context, code int, name string, ob
 	if c == nil {
 		return
 	}
+	if c.Request != nil && utils.FilterHttpRequest(c.Request.Method, c.Request.URL, c.Request.UserAgent()) {
+		return
+	}
+	if c.Request != nil && http.UpdateHttpRoute(c.Request.Context(), c.FullPath()) {
+		return
+	}
 	lcs := trace.LocalRootSpanFromGLS()
 	if lcs != nil && c.FullPath() != "" && c.Request != nil && c.Request.URL != nil && (c.FullPath() != c.Request.URL.Path) {
 		lcs.SetName(c.FullPath())
@@ -19,6 +19,23 @@ import (
 )
 
 type goRedisRequest struct {
-	cmd      redis.Cmder
+	cmd redis.Cmder
+	// the commands of a pipeline or a transaction, cmd is nil for them
+	cmds     []redis.Cmder
+	txn      bool
 	endpoint string
 }
+
+// newBatchRequest strips the multi and exec wrapping the commands of a
+// transaction, they are recorded as the transaction span itself.
+func newBatchRequest(cmds []redis.Cmder, endpoint string) goRedisRequest {
+	request := goRedisRequest{cmds: cmds, endpoint: endpoint}
+	if n := len(cmds); n >= 2 && cmds[0].Name() == "multi" && cmds[n-1].Name() == "exec" {
+		request.cmds, request.txn = cmds[1:n-1], true
+	}
+	return request
+}
+
+func (r goRedisRequest) isBatch() bool {
+	return r.cmd == nil
+}
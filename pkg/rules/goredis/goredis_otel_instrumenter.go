@@ -23,6 +23,7 @@ import (
 	redis "github.com/redis/go-redis/v9"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
 	"strconv"
+	"strings"
 	"time"
 	"unicode/utf8"
 	"unsafe"
@@ -39,22 +40,67 @@ func (d goRedisAttrsGetter) GetServerAddress(request goRedisRequest) string {
 	return request.endpoint
 }
 
+// the commands summarized in the statement of a pipeline
+const maxSummaryCmds = 10
+
 func (d goRedisAttrsGetter) GetStatement(request goRedisRequest) string {
+	if request.isBatch() {
+		return batchSummary(request.cmds)
+	}
+	return cmdStatement(request.cmd)
+}
+
+// GetSanitizedStatement keeps the summary of a pipeline as it only holds the
+// command names.
+func (d goRedisAttrsGetter) GetSanitizedStatement(request goRedisRequest) string {
+	if request.isBatch() {
+		return batchSummary(request.cmds)
+	}
+	return db.SanitizeRedis(cmdStatement(request.cmd))
+}
+
+func (d goRedisAttrsGetter) GetBatchOperations(request goRedisRequest) []db.DbBatchOperation {
+	if !request.isBatch() {
+		return nil
+	}
+	operations := make([]db.DbBatchOperation, len(request.cmds))
+	for i, cmd := range request.cmds {
+		operations[i] = db.DbBatchOperation{Operation: cmd.FullName(), Statement: cmdStatement(cmd)}
+		if err := cmd.Err(); err != nil && err != redis.Nil {
+			operations[i].Err = err
+		}
+	}
+	return operations
+}
+
+func batchSummary(cmds []redis.Cmder) string {
+	summary := make([]string, 0, min(len(cmds), maxSummaryCmds)+1)
+	for i, cmd := range cmds {
+		if i >= maxSummaryCmds {
+			summary = append(summary, "...")
+			break
+		}
+		summary = append(summary, cmd.FullName())
+	}
+	return strings.Join(summary, "/")
+}
+
+func cmdStatement(cmd redis.Cmder) string {
 	b := make([]byte, 0, 64)
 
-	for i, arg := range request.cmd.Args() {
+	for i, arg := range cmd.Args() {
 		if i > 0 {
 			b = append(b, ' ')
 		}
 		b = redisV9AppendArg(b, arg)
 	}
 
-	if err := request.cmd.Err(); err != nil {
+	if err := cmd.Err(); err != nil {
 		b = append(b, ": "...)
 		b = append(b, err.Error()...)
 	}
 
-	if cmd, ok := request.cmd.(*redis.Cmd); ok {
+	if cmd, ok := cmd.(*redis.Cmd); ok {
 		b = append(b, ": "...)
 		b = redisV9AppendArg(b, cmd)
 	}
@@ -63,7 +109,13 @@ func (d goRedisAttrsGetter) GetStatement(request goRedisRequest) string {
 }
 
 func (d goRedisAttrsGetter) GetOperation(request goRedisRequest) string {
-	return request.cmd.FullName()
+	if !request.isBatch() {
+		return request.cmd.FullName()
+	}
+	if request.txn {
+		return "multi"
+	}
+	return "pipeline"
 }
 
 func (d goRedisAttrsGetter) GetCollection(request goRedisRequest) string {
@@ -80,7 +132,11 @@ func (d goRedisAttrsGetter) GetDbNamespace(request goRedisRequest) string {
 }
 
 func (d goRedisAttrsGetter) GetBatchSize(request goRedisRequest) int {
-	return 0
+	// a single command is not considered as a batch
+	if !request.isBatch() || len(request.cmds) < 2 {
+		return 0
+	}
+	return len(request.cmds)
 }
 
 func BuildGoRedisOtelInstrumenter() instrumenter.Instrumenter[goRedisRequest, any] {
@@ -0,0 +1,92 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goredis
+
+import (
+	"log"
+	"net"
+	"reflect"
+	"strconv"
+
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api-semconv/instrumenter/db"
+	redis "github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// registerPool observes the pool of the client until the client is closed.
+func registerPool(client *redis.Client) {
+	opts := client.Options()
+	name := opts.Addr
+	if opts.DB != 0 {
+		name += "/" + strconv.Itoa(opts.DB)
+	}
+	host, _, err := net.SplitHostPort(opts.Addr)
+	if err != nil {
+		// e.g. the failover clients
+		host = ""
+	}
+	// no limit by default
+	idleMax := int64(-1)
+	if opts.MaxIdleConns > 0 {
+		idleMax = int64(opts.MaxIdleConns)
+	}
+	reg, err := db.RegisterDbPoolMetrics(db.DbPoolName(name), host, func() db.DbPoolStats {
+		stats := client.PoolStats()
+		return db.DbPoolStats{
+			Idle:     int64(stats.IdleConns),
+			Used:     max(int64(stats.TotalConns)-int64(stats.IdleConns), 0),
+			Max:      int64(opts.PoolSize),
+			IdleMax:  idleMax,
+			Timeouts: int64(stats.Timeouts),
+			// go-redis does not measure the wait time
+			WaitTime: -1,
+		}
+	})
+	if err != nil {
+		log.Printf("failed to register the redis pool metrics: %v", err)
+		return
+	}
+	// the field is injected into the baseClient embedded in redis.Client
+	client.OtelPool = reg
+}
+
+// unregisterPool stops observing the pool kept in the OtelPool field of a
+// closed client.
+func unregisterPool(pool interface{}) {
+	reg, ok := pool.(metric.Registration)
+	if !ok {
+		return
+	}
+	if err := reg.Unregister(); err != nil {
+		log.Printf("failed to unregister the redis pool metrics: %v", err)
+	}
+}
+
+// takeBaseClientPool clears the OtelPool field of the baseClient embedded in
+// redis.Client, Close is a method of the unexported baseClient so its field is
+// reached by reflection.
+func takeBaseClientPool(baseClient interface{}) interface{} {
+	v := reflect.ValueOf(baseClient)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return nil
+	}
+	field := v.Elem().FieldByName("OtelPool")
+	if !field.IsValid() || !field.CanSet() {
+		return nil
+	}
+	pool := field.Interface()
+	field.Set(reflect.Zero(field.Type()))
+	return pool
+}
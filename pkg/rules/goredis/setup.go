@@ -17,11 +17,11 @@ package goredis
 import (
 	"context"
 	"net"
-	"os"
 	"strings"
 	_ "unsafe"
 
 	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/api"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/instrumenter"
 	"go.opentelemetry.io/otel/trace"
 
 	redis "github.com/redis/go-redis/v9"
@@ -29,15 +29,7 @@ import (
 
 var goRedisInstrumenter = BuildGoRedisOtelInstrumenter()
 
-type redisV9InnerEnabler struct {
-	enabled bool
-}
-
-func (r redisV9InnerEnabler) Enable() bool {
-	return r.enabled
-}
-
-var rv9Enabler = redisV9InnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_REDISV9_ENABLED") != "false"}
+var rv9Enabler = instrumenter.RegisterInstrumentEnabler("redisv9", true)
 
 var redisV9StartOptions = []trace.SpanStartOption{}
 
@@ -47,6 +39,7 @@ func afterNewRedisClient(call api.CallContext, client *redis.Client) {
 		return
 	}
 	client.AddHook(newOtRedisHook(client.Options().Addr))
+	registerPool(client)
 }
 
 //go:linkname afterNewFailOverRedisClient github.com/redis/go-redis/v9.afterNewFailOverRedisClient
@@ -55,6 +48,15 @@ func afterNewFailOverRedisClient(call api.CallContext, client *redis.Client) {
 		return
 	}
 	client.AddHook(newOtRedisHook(client.Options().Addr))
+	registerPool(client)
+}
+
+//go:linkname beforeRedisClientClose github.com/redis/go-redis/v9.beforeRedisClientClose
+func beforeRedisClientClose(call api.CallContext, baseClient interface{}) {
+	if !rv9Enabler.Enable() {
+		return
+	}
+	unregisterPool(takeBaseClientPool(baseClient))
 }
 
 //go:linkname afterNewClusterClient github.com/redis/go-redis/v9.afterNewClusterClient
@@ -64,6 +66,7 @@ func afterNewClusterClient(call api.CallContext, client *redis.ClusterClient) {
 	}
 	client.OnNewNode(func(rdb *redis.Client) {
 		rdb.AddHook(newOtRedisHook(rdb.Options().Addr))
+		registerPool(rdb)
 	})
 }
 
@@ -74,6 +77,7 @@ func afterNewRingClient(call api.CallContext, client *redis.Ring) {
 	}
 	client.OnNewNode(func(rdb *redis.Client) {
 		rdb.AddHook(newOtRedisHook(rdb.Options().Addr))
+		registerPool(rdb)
 	})
 }
 
@@ -134,22 +138,7 @@ func (o *otRedisHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
 
 func (o *otRedisHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
 	return func(ctx context.Context, cmds []redis.Cmder) error {
-		summary := ""
-		summaryCmds := cmds
-		if len(summaryCmds) > 10 {
-			summaryCmds = summaryCmds[:10]
-		}
-		for i := range summaryCmds {
-			summary += summaryCmds[i].FullName() + "/"
-		}
-		if len(cmds) > 10 {
-			summary += "..."
-		}
-		cmd := redis.NewCmd(ctx, "pipeline", summary)
-		request := goRedisRequest{
-			cmd:      cmd,
-			endpoint: o.Addr,
-		}
+		request := newBatchRequest(cmds, o.Addr)
 		ctx = goRedisInstrumenter.Start(ctx, request)
 		if err := next(ctx, cmds); err != nil {
 			goRedisInstrumenter.End(ctx, request, nil, err)
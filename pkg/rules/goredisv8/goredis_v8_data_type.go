@@ -15,7 +15,6 @@
 package goredisv8
 
 import (
-	"context"
 	"fmt"
 	"github.com/go-redis/redis/v8"
 	"strconv"
@@ -26,13 +25,28 @@ import (
 
 const redisV8Context = "redis-v8-context"
 
-var pipelineCmd = redis.NewCmd(context.Background(), "pipeline")
-
 type redisv8Data struct {
-	cmd  redis.Cmder
+	cmd redis.Cmder
+	// the commands of a pipeline or a transaction, cmd is nil for them
+	cmds []redis.Cmder
+	txn  bool
 	Host string
 }
 
+// newBatchData strips the multi and exec wrapping the commands of a
+// transaction, they are recorded as the transaction span itself.
+func newBatchData(cmds []redis.Cmder, host string) redisv8Data {
+	data := redisv8Data{cmds: cmds, Host: host}
+	if n := len(cmds); n >= 2 && cmds[0].Name() == "multi" && cmds[n-1].Name() == "exec" {
+		data.cmds, data.txn = cmds[1:n-1], true
+	}
+	return data
+}
+
+func (r redisv8Data) isBatch() bool {
+	return r.cmd == nil
+}
+
 func redisV8String(b []byte) string {
 	return *(*string)(unsafe.Pointer(&b))
 }
@@ -21,6 +21,7 @@ import (
 	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/version"
 	"github.com/go-redis/redis/v8"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"strings"
 )
 
 type goRedisV8AttrsGetter struct {
@@ -39,25 +40,74 @@ func (d goRedisV8AttrsGetter) GetDbNamespace(request redisv8Data) string {
 }
 
 func (d goRedisV8AttrsGetter) GetBatchSize(request redisv8Data) int {
-	return 0
+	// a single command is not considered as a batch
+	if !request.isBatch() || len(request.cmds) < 2 {
+		return 0
+	}
+	return len(request.cmds)
 }
 
+// the commands summarized in the statement of a pipeline
+const maxSummaryCmds = 10
+
 func (d goRedisV8AttrsGetter) GetStatement(request redisv8Data) string {
+	if request.isBatch() {
+		return batchSummary(request.cmds)
+	}
+	return cmdStatement(request.cmd)
+}
+
+// GetSanitizedStatement keeps the summary of a pipeline as it only holds the
+// command names.
+func (d goRedisV8AttrsGetter) GetSanitizedStatement(request redisv8Data) string {
+	if request.isBatch() {
+		return batchSummary(request.cmds)
+	}
+	return db.SanitizeRedis(cmdStatement(request.cmd))
+}
+
+func (d goRedisV8AttrsGetter) GetBatchOperations(request redisv8Data) []db.DbBatchOperation {
+	if !request.isBatch() {
+		return nil
+	}
+	operations := make([]db.DbBatchOperation, len(request.cmds))
+	for i, cmd := range request.cmds {
+		operations[i] = db.DbBatchOperation{Operation: cmd.FullName(), Statement: cmdStatement(cmd)}
+		if err := cmd.Err(); err != nil && err != redis.Nil {
+			operations[i].Err = err
+		}
+	}
+	return operations
+}
+
+func batchSummary(cmds []redis.Cmder) string {
+	summary := make([]string, 0, min(len(cmds), maxSummaryCmds)+1)
+	for i, cmd := range cmds {
+		if i >= maxSummaryCmds {
+			summary = append(summary, "...")
+			break
+		}
+		summary = append(summary, cmd.FullName())
+	}
+	return strings.Join(summary, "/")
+}
+
+func cmdStatement(cmd redis.Cmder) string {
 	b := make([]byte, 0, 64)
 
-	for i, arg := range request.cmd.Args() {
+	for i, arg := range cmd.Args() {
 		if i > 0 {
 			b = append(b, ' ')
 		}
 		b = redisV8AppendArg(b, arg)
 	}
 
-	if err := request.cmd.Err(); err != nil && err != redis.Nil {
+	if err := cmd.Err(); err != nil && err != redis.Nil {
 		b = append(b, ": "...)
 		b = append(b, err.Error()...)
 	}
 
-	if cmd, ok := request.cmd.(*redis.Cmd); ok {
+	if cmd, ok := cmd.(*redis.Cmd); ok {
 		b = append(b, ": "...)
 		b = redisV8AppendArg(b, cmd)
 	}
@@ -70,7 +120,13 @@ func (d goRedisV8AttrsGetter) GetCollection(request redisv8Data) string {
 }
 
 func (d goRedisV8AttrsGetter) GetOperation(request redisv8Data) string {
-	return request.cmd.FullName()
+	if !request.isBatch() {
+		return request.cmd.FullName()
+	}
+	if request.txn {
+		return "multi"
+	}
+	return "pipeline"
 }
 
 func (d goRedisV8AttrsGetter) GetParameters(request redisv8Data) []any {
@@ -3,26 +3,18 @@ package goredisv8
 import (
 	"context"
 	"errors"
-	"os"
 	"strings"
 	_ "unsafe"
 
 	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/api"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/instrumenter"
 	redis "github.com/go-redis/redis/v8"
 	"go.opentelemetry.io/otel/trace"
 )
 
 var redisv8Instrumenter = BuildRedisv8Instrumenter()
 
-type redisV8InnerEnabler struct {
-	enabled bool
-}
-
-func (g redisV8InnerEnabler) Enable() bool {
-	return g.enabled
-}
-
-var rv8Enabler = redisV8InnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_REDISV8_ENABLED") != "false"}
+var rv8Enabler = instrumenter.RegisterInstrumentEnabler("redisv8", true)
 
 var redisV8StartOptions = []trace.SpanStartOption{}
 
@@ -32,6 +24,7 @@ func afterNewRedisV8Client(call api.CallContext, client *redis.Client) {
 		return
 	}
 	client.AddHook(newOtRedisV8Hook(client.Options().Addr))
+	registerPool(client)
 }
 
 //go:linkname afterNewFailOverRedisV8Client github.com/go-redis/redis/v8.afterNewFailOverRedisV8Client
@@ -40,6 +33,7 @@ func afterNewFailOverRedisV8Client(call api.CallContext, client *redis.Client) {
 		return
 	}
 	client.AddHook(newOtRedisV8Hook(client.Options().Addr))
+	registerPool(client)
 }
 
 //go:linkname afterNewConnRedisV8Client github.com/go-redis/redis/v8.afterNewConnRedisV8Client
@@ -55,7 +49,11 @@ func afterNewClusterV8Client(call api.CallContext, client *redis.ClusterClient)
 	if !rv8Enabler.Enable() {
 		return
 	}
-	client.AddHook(newOtRedisV8Hook(strings.Join(client.Options().Addrs, ",")))
+	addrs := strings.Join(client.Options().Addrs, ",")
+	client.AddHook(newOtRedisV8Hook(addrs))
+	if reg := registerAggregatedPool(addrs, client.PoolStats); reg != nil {
+		client.OtelPool = reg
+	}
 }
 
 //go:linkname afterNewRingV8Client github.com/go-redis/redis/v8.afterNewRingV8Client
@@ -68,6 +66,37 @@ func afterNewRingV8Client(call api.CallContext, client *redis.Ring) {
 		addrBuilder.WriteString(addr)
 	}
 	client.AddHook(newOtRedisV8Hook(addrBuilder.String()))
+	if reg := registerAggregatedPool(addrBuilder.String(), client.PoolStats); reg != nil {
+		client.OtelPool = reg
+	}
+}
+
+//go:linkname beforeRedisV8ClientClose github.com/go-redis/redis/v8.beforeRedisV8ClientClose
+func beforeRedisV8ClientClose(call api.CallContext, baseClient interface{}) {
+	if !rv8Enabler.Enable() {
+		return
+	}
+	unregisterPool(takeBaseClientPool(baseClient))
+}
+
+//go:linkname beforeClusterV8ClientClose github.com/go-redis/redis/v8.beforeClusterV8ClientClose
+func beforeClusterV8ClientClose(call api.CallContext, client *redis.ClusterClient) {
+	if !rv8Enabler.Enable() || client == nil {
+		return
+	}
+	pool := client.OtelPool
+	client.OtelPool = nil
+	unregisterPool(pool)
+}
+
+//go:linkname beforeRingV8ClientClose github.com/go-redis/redis/v8.beforeRingV8ClientClose
+func beforeRingV8ClientClose(call api.CallContext, client *redis.Ring) {
+	if !rv8Enabler.Enable() || client == nil {
+		return
+	}
+	pool := client.OtelPool
+	client.OtelPool = nil
+	unregisterPool(pool)
 }
 
 type otRedisV8Hook struct {
@@ -104,20 +133,14 @@ func (o *otRedisV8Hook) AfterProcess(ctx context.Context, cmd redis.Cmder) error
 }
 
 func (o *otRedisV8Hook) BeforeProcessPipeline(ctx context.Context, cmds []redis.Cmder) (context.Context, error) {
-	request := redisv8Data{
-		cmd:  pipelineCmd,
-		Host: o.Addr,
-	}
+	request := newBatchData(cmds, o.Addr)
 	newCtx := redisv8Instrumenter.Start(ctx, request, redisV8StartOptions...)
 	ctx = context.WithValue(ctx, redisV8Context, newCtx)
 	return ctx, nil
 }
 
 func (o *otRedisV8Hook) AfterProcessPipeline(ctx context.Context, cmds []redis.Cmder) error {
-	request := redisv8Data{
-		cmd:  pipelineCmd,
-		Host: o.Addr,
-	}
+	request := newBatchData(cmds, o.Addr)
 	var tError error
 	hasError := false
 	errSb := strings.Builder{}
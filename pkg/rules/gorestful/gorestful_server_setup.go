@@ -16,23 +16,16 @@ package gorestful
 
 import (
 	"net/http"
-	"os"
 	_ "unsafe"
 
 	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/api"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/instrumenter"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/utils"
 	restful "github.com/emicklei/go-restful/v3"
 	"go.opentelemetry.io/otel/sdk/trace"
 )
 
-type goRestfulInnerEnabler struct {
-	enabled bool
-}
-
-func (g goRestfulInnerEnabler) Enable() bool {
-	return g.enabled
-}
-
-var goRestfulEnabler = goRestfulInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_GORESTFUL_ENABLED") != "false"}
+var goRestfulEnabler = instrumenter.RegisterInstrumentEnabler("gorestful", true)
 
 //go:linkname restContainerAddOnEnter github.com/emicklei/go-restful/v3.restContainerAddOnEnter
 func restContainerAddOnEnter(call api.CallContext, c *restful.Container, service *restful.WebService) {
@@ -71,7 +64,7 @@ var filterRest = func(req *restful.Request, resp *restful.Response, chain *restf
 	if !goRestfulEnabler.Enable() {
 		return
 	}
-	if req == nil {
+	if req == nil || (req.Request != nil && utils.FilterHttpRequest(req.Request.Method, req.Request.URL, req.Request.UserAgent())) {
 		return
 	}
 	lcs := trace.LocalRootSpanFromGLS()
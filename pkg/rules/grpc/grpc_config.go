@@ -16,7 +16,10 @@ package grpc
 
 import (
 	"context"
+	"os"
+	"strconv"
 
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api-semconv/instrumenter/rpc"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/propagation"
 	semconv "go.opentelemetry.io/otel/semconv/v1.19.0"
@@ -25,6 +28,13 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+const (
+	// set to "true" to add an event for every received message
+	grpcReceivedEventEnv = "OTEL_INSTRUMENTATION_GRPC_RECEIVED_MESSAGE_EVENT_ENABLED"
+	// set to "true" to add an event for every sent message
+	grpcSentEventEnv = "OTEL_INSTRUMENTATION_GRPC_SENT_MESSAGE_EVENT_ENABLED"
+)
+
 type Filter func(*InterceptorInfo) bool
 
 // grpcOtelConfig is a group of options for this instrumentation.
@@ -52,14 +62,20 @@ func (c *grpcOtelConfig) handleRPC(ctx context.Context, rs stats.RPCStats, isSer
 	if span == nil {
 		return
 	}
-	var (
-		messageId int64
-	)
 	gctx, _ := ctx.Value(gRPCContextKey{}).(*gRPCContext)
 	switch rs := rs.(type) {
 	case *stats.Begin:
 	case *stats.InPayload:
+		if isServer {
+			rpc.RecordRpcRequestMessage(ctx, int64(rs.Length))
+		} else {
+			rpc.RecordRpcResponseMessage(ctx, int64(rs.Length))
+		}
 		if c.ReceivedEvent {
+			var messageId int64
+			if gctx != nil {
+				messageId = gctx.receivedMessages.Add(1)
+			}
 			span.AddEvent("message",
 				trace.WithAttributes(
 					semconv.MessageTypeReceived,
@@ -69,7 +85,16 @@ func (c *grpcOtelConfig) handleRPC(ctx context.Context, rs stats.RPCStats, isSer
 			)
 		}
 	case *stats.OutPayload:
+		if isServer {
+			rpc.RecordRpcResponseMessage(ctx, int64(rs.Length))
+		} else {
+			rpc.RecordRpcRequestMessage(ctx, int64(rs.Length))
+		}
 		if c.SentEvent {
+			var messageId int64
+			if gctx != nil {
+				messageId = gctx.sentMessages.Add(1)
+			}
 			span.AddEvent("message",
 				trace.WithAttributes(
 					semconv.MessageTypeSent,
@@ -121,7 +146,9 @@ func (c *grpcOtelConfig) handleRPC(ctx context.Context, rs stats.RPCStats, isSer
 // newConfig returns a grpcOtelConfig configured with all the passed Options.
 func newConfig(opts []Option, role string) *grpcOtelConfig {
 	c := &grpcOtelConfig{
-		Propagators: otel.GetTextMapPropagator(),
+		Propagators:   otel.GetTextMapPropagator(),
+		ReceivedEvent: envBool(grpcReceivedEventEnv),
+		SentEvent:     envBool(grpcSentEventEnv),
 	}
 	for _, o := range opts {
 		o.apply(c)
@@ -130,6 +157,11 @@ func newConfig(opts []Option, role string) *grpcOtelConfig {
 	return c
 }
 
+func envBool(name string) bool {
+	val, err := strconv.ParseBool(os.Getenv(name))
+	return err == nil && val
+}
+
 type propagatorsOption struct{ p propagation.TextMapPropagator }
 
 func (o propagatorsOption) apply(c *grpcOtelConfig) {
@@ -15,6 +15,8 @@
 package grpc
 
 import (
+	"sync/atomic"
+
 	"go.opentelemetry.io/otel/propagation"
 )
 
@@ -39,4 +41,7 @@ type gRPCContextKey struct{}
 
 type gRPCContext struct {
 	methodName string
+	// the sequence of the messages, they are the ids of the message events
+	sentMessages     atomic.Int64
+	receivedMessages atomic.Int64
 }
@@ -16,28 +16,25 @@ package client
 
 import (
 	"context"
-	"os"
+	"net/url"
 	_ "unsafe"
 
 	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/api"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/instrumenter"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/utils"
 	"github.com/cloudwego/hertz/pkg/app/client"
 	"github.com/cloudwego/hertz/pkg/protocol"
 )
 
-type hertzClientInnerEnabler struct {
-	enabled bool
-}
-
-func (h hertzClientInnerEnabler) Enable() bool {
-	return h.enabled
-}
-
-var hertzClientEnabler = hertzClientInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_HERTZ_ENABLED") != "false"}
+var hertzClientEnabler = instrumenter.RegisterInstrumentEnabler("hertz", true)
 
 var hertzClientInstrumenter = BuildHertzClientInstrumenter()
 
 func otelClientMiddleware(next client.Endpoint) client.Endpoint {
 	return func(ctx context.Context, req *protocol.Request, resp *protocol.Response) (err error) {
+		if utils.FilterHttpClientRequest(string(req.Method()), &url.URL{Path: string(req.URI().Path())}, string(req.Header.UserAgent())) {
+			return next(ctx, req, resp)
+		}
 		ctx = hertzClientInstrumenter.Start(ctx, req)
 		err = next(ctx, req, resp)
 		if err != nil {
@@ -44,23 +44,53 @@ func (h hertzHttpClientAttrsGetter) GetRequestMethod(request *protocol.Request)
 }
 
 func (h hertzHttpClientAttrsGetter) GetHttpRequestHeader(request *protocol.Request, name string) []string {
-	keys := make([]string, 0)
-	request.Header.VisitAllCustomHeader(func(key, value []byte) {
-		keys = append(keys, string(key))
-	})
-	return keys
+	all := make([]string, 0)
+	for _, header := range request.Header.PeekAll(name) {
+		all = append(all, string(header))
+	}
+	return all
 }
 
 func (h hertzHttpClientAttrsGetter) GetHttpResponseStatusCode(request *protocol.Request, response *protocol.Response, err error) int {
 	return response.StatusCode()
 }
 
+// the streamed bodies are skipped as reading them would consume the stream
+func (h hertzHttpClientAttrsGetter) GetHttpRequestBody(request *protocol.Request) *http.BodyBuffer {
+	if !http.BodyCaptureEnabled() || request.IsBodyStream() {
+		return nil
+	}
+	return http.CaptureBody(string(request.Header.ContentType()), request.Body())
+}
+
+func (h hertzHttpClientAttrsGetter) GetHttpResponseBody(request *protocol.Request, response *protocol.Response) *http.BodyBuffer {
+	if !http.BodyCaptureEnabled() || response.IsBodyStream() {
+		return nil
+	}
+	return http.CaptureBody(string(response.Header.ContentType()), response.Body())
+}
+
+// the size of a streamed body is only known by its content length
+func (h hertzHttpClientAttrsGetter) GetHttpRequestBodySize(request *protocol.Request) int64 {
+	if request.IsBodyStream() {
+		return int64(request.Header.ContentLength())
+	}
+	return int64(len(request.Body()))
+}
+
+func (h hertzHttpClientAttrsGetter) GetHttpResponseBodySize(request *protocol.Request, response *protocol.Response) int64 {
+	if response.IsBodyStream() {
+		return int64(response.Header.ContentLength())
+	}
+	return int64(len(response.Body()))
+}
+
 func (h hertzHttpClientAttrsGetter) GetHttpResponseHeader(request *protocol.Request, response *protocol.Response, name string) []string {
-	keys := make([]string, 0)
-	response.Header.VisitAll(func(key, value []byte) {
-		keys = append(keys, string(key))
-	})
-	return keys
+	all := make([]string, 0)
+	for _, header := range response.Header.PeekAll(name) {
+		all = append(all, string(header))
+	}
+	return all
 }
 
 func (h hertzHttpClientAttrsGetter) GetErrorType(request *protocol.Request, response *protocol.Response, err error) string {
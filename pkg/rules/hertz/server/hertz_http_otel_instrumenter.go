@@ -44,23 +44,53 @@ func (n hertzHttpServerAttrsGetter) GetRequestMethod(request *protocol.Request)
 }
 
 func (n hertzHttpServerAttrsGetter) GetHttpRequestHeader(request *protocol.Request, name string) []string {
-	keys := make([]string, 0)
-	request.Header.VisitAll(func(key, value []byte) {
-		keys = append(keys, string(key))
-	})
-	return keys
+	all := make([]string, 0)
+	for _, header := range request.Header.PeekAll(name) {
+		all = append(all, string(header))
+	}
+	return all
 }
 
 func (n hertzHttpServerAttrsGetter) GetHttpResponseStatusCode(request *protocol.Request, response *protocol.Response, err error) int {
 	return response.StatusCode()
 }
 
+// the streamed bodies are skipped as reading them would consume the stream
+func (n hertzHttpServerAttrsGetter) GetHttpRequestBody(request *protocol.Request) *http.BodyBuffer {
+	if !http.BodyCaptureEnabled() || request.IsBodyStream() {
+		return nil
+	}
+	return http.CaptureBody(string(request.Header.ContentType()), request.Body())
+}
+
+func (n hertzHttpServerAttrsGetter) GetHttpResponseBody(request *protocol.Request, response *protocol.Response) *http.BodyBuffer {
+	if !http.BodyCaptureEnabled() || response.IsBodyStream() {
+		return nil
+	}
+	return http.CaptureBody(string(response.Header.ContentType()), response.Body())
+}
+
+// the size of a streamed body is only known by its content length
+func (n hertzHttpServerAttrsGetter) GetHttpRequestBodySize(request *protocol.Request) int64 {
+	if request.IsBodyStream() {
+		return int64(request.Header.ContentLength())
+	}
+	return int64(len(request.Body()))
+}
+
+func (n hertzHttpServerAttrsGetter) GetHttpResponseBodySize(request *protocol.Request, response *protocol.Response) int64 {
+	if response.IsBodyStream() {
+		return int64(response.Header.ContentLength())
+	}
+	return int64(len(response.Body()))
+}
+
 func (n hertzHttpServerAttrsGetter) GetHttpResponseHeader(request *protocol.Request, response *protocol.Response, name string) []string {
-	keys := make([]string, 0)
-	response.Header.VisitAll(func(key, value []byte) {
-		keys = append(keys, string(key))
-	})
-	return keys
+	all := make([]string, 0)
+	for _, header := range response.Header.PeekAll(name) {
+		all = append(all, string(header))
+	}
+	return all
 }
 
 func (n hertzHttpServerAttrsGetter) GetErrorType(request *protocol.Request, response *protocol.Response, err error) string {
@@ -164,6 +194,7 @@ func BuildHertzServerInstrumenter() *instrumenter.PropagatingFromUpstreamInstrum
 	return builder.Init().SetSpanStatusExtractor(http.HttpServerSpanStatusExtractor[*protocol.Request, *protocol.Response]{Getter: serverGetter}).SetSpanNameExtractor(&http.HttpServerSpanNameExtractor[*protocol.Request, *protocol.Response]{Getter: serverGetter}).
 		SetSpanKindExtractor(&instrumenter.AlwaysServerExtractor[*protocol.Request]{}).
 		AddOperationListeners(http.HttpServerMetrics("hertz.server")).
+		AddContextCustomizers(&http.HttpRouteHolder[*protocol.Request, *protocol.Response]{Getter: serverGetter}).
 		SetInstrumentationScope(instrumentation.Scope{
 			Name:    utils.HERTZ_HTTP_SERVER_SCOPE_NAME,
 			Version: version.Tag,
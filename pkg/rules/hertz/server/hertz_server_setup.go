@@ -16,25 +16,20 @@ package server
 
 import (
 	"context"
-	"os"
+	"net/url"
 	_ "unsafe"
 
 	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/api"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api-semconv/instrumenter/http"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/instrumenter"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/utils"
 	"github.com/cloudwego/hertz/pkg/app"
 	"github.com/cloudwego/hertz/pkg/app/server"
 	"github.com/cloudwego/hertz/pkg/common/config"
 	"github.com/cloudwego/hertz/pkg/common/tracer/stats"
 )
 
-type hertzServerInnerEnabler struct {
-	enabled bool
-}
-
-func (h hertzServerInnerEnabler) Enable() bool {
-	return h.enabled
-}
-
-var hertzServerEnabler = hertzServerInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_HERTZ_ENABLED") != "false"}
+var hertzServerEnabler = instrumenter.RegisterInstrumentEnabler("hertz", true)
 
 var hertzInstrumenter = BuildHertzServerInstrumenter()
 
@@ -45,6 +40,9 @@ func (m *hertzOpentelemetryTracer) Start(ctx context.Context, c *app.RequestCont
 }
 
 func (m *hertzOpentelemetryTracer) Finish(ctx context.Context, c *app.RequestContext) {
+	if utils.FilterHttpRequest(string(c.Request.Method()), &url.URL{Path: string(c.Request.URI().Path())}, string(c.Request.Header.UserAgent())) {
+		return
+	}
 	if c.GetTraceInfo().Stats().GetEvent(stats.HTTPStart) != nil && c.GetTraceInfo().Stats().GetEvent(stats.HTTPFinish) != nil {
 		start := c.GetTraceInfo().Stats().GetEvent(stats.HTTPStart)
 		end := c.GetTraceInfo().Stats().GetEvent(stats.HTTPFinish)
@@ -53,6 +51,10 @@ func (m *hertzOpentelemetryTracer) Finish(ctx context.Context, c *app.RequestCon
 		}
 		s := start.Time()
 		e := end.Time()
+		// the route is resolved by the time the request is finished
+		if route := c.FullPath(); route != "" {
+			ctx = http.ContextWithHttpRoute(ctx, route)
+		}
 		req, resp := &c.Request, &c.Response
 		hertzInstrumenter.StartAndEnd(ctx, req, resp, c.GetTraceInfo().Stats().Error(), s, e)
 	}
@@ -21,12 +21,10 @@ import (
 	_ "unsafe"
 
 	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/api"
+	semhttp "github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api-semconv/instrumenter/http"
 	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/utils"
 )
 
-// TODO: use a interface to filter
-var netHttpFilter = utils.DefaultUrlFilter{}
-
 var netHttpClientInstrumenter = BuildNetHttpClientOtelInstrumenter()
 
 const otelExporterPrefix = "OTel OTLP Exporter Go"
@@ -40,7 +38,7 @@ func clientOnEnter(call api.CallContext, t *http.Transport, req *http.Request) {
 	if strings.HasPrefix(req.Header.Get("user-agent"), otelExporterPrefix) {
 		return
 	}
-	if netHttpFilter.FilterUrl(req.URL) {
+	if utils.FilterHttpClientRequest(req.Method, req.URL, req.UserAgent()) {
 		return
 	}
 	netHttpRequest := &netHttpRequest{
@@ -53,9 +51,13 @@ func clientOnEnter(call api.CallContext, t *http.Transport, req *http.Request) {
 	netHttpRequest.version = getProtocolVersion(req.ProtoMajor, req.ProtoMinor)
 	ctx := netHttpClientInstrumenter.Start(req.Context(), netHttpRequest)
 	req = req.WithContext(ctx)
+	if semhttp.BodyCaptureEnabled() {
+		req.Body, netHttpRequest.body = semhttp.CaptureBodyReader(req.Body, req.Header.Get("Content-Type"))
+	}
 	call.SetParam(1, req)
-	data := make(map[string]interface{}, 1)
+	data := make(map[string]interface{}, 2)
 	data["ctx"] = ctx
+	data["request"] = netHttpRequest
 	call.SetData(data)
 	return
 }
@@ -71,20 +73,40 @@ func clientOnExit(call api.CallContext, res *http.Response, err error) {
 	}
 	ctx := data["ctx"].(context.Context)
 	if res != nil {
-		netHttpClientInstrumenter.End(ctx, &netHttpRequest{
+		request := &netHttpRequest{
 			method:  res.Request.Method,
 			url:     res.Request.URL,
 			header:  res.Request.Header,
 			version: getProtocolVersion(res.Request.ProtoMajor, res.Request.ProtoMinor),
 			host:    res.Request.Host,
 			isTls:   res.Request.TLS != nil,
-		}, &netHttpResponse{
+			// the wrapped body, if any, still tells the size
+			bodySize: getRequestBodySize(res.Request),
+		}
+		response := &netHttpResponse{
 			statusCode: res.StatusCode,
 			header:     res.Header,
-		}, err)
+			bodySize:   res.ContentLength,
+		}
+		if semhttp.BodyCaptureEnabled() {
+			if r, ok := data["request"].(*netHttpRequest); ok {
+				request.body = r.body
+			}
+			// the response body is read by the caller after the round trip,
+			// the span ends once it is consumed or closed to record it
+			res.Body, response.body = semhttp.CaptureResponseBodyReader(res.Body, res.Header.Get("Content-Type"), func() {
+				netHttpClientInstrumenter.End(ctx, request, response, err)
+			})
+			if response.body != nil {
+				return
+			}
+		}
+		netHttpClientInstrumenter.End(ctx, request, response, err)
 	} else {
-		netHttpClientInstrumenter.End(ctx, &netHttpRequest{}, &netHttpResponse{
+		// the body sizes of a failed request are not reported
+		netHttpClientInstrumenter.End(ctx, &netHttpRequest{bodySize: -1}, &netHttpResponse{
 			statusCode: 500,
+			bodySize:   -1,
 		}, err)
 	}
 }
@@ -18,6 +18,8 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+
+	semhttp "github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api-semconv/instrumenter/http"
 )
 
 type netHttpRequest struct {
@@ -27,11 +29,29 @@ type netHttpRequest struct {
 	isTls   bool
 	header  http.Header
 	version string
+	// body is nil unless the body is captured
+	body *semhttp.BodyBuffer
+	// bodySize is negative when unknown
+	bodySize int64
 }
 
 type netHttpResponse struct {
 	statusCode int
 	header     http.Header
+	body       *semhttp.BodyBuffer
+	bodySize   int64
+}
+
+// getRequestBodySize returns -1 when the length of a non-empty body is not
+// known in advance.
+func getRequestBodySize(r *http.Request) int64 {
+	if r.Body == nil || r.Body == http.NoBody {
+		return 0
+	}
+	if r.ContentLength == 0 {
+		return -1
+	}
+	return r.ContentLength
 }
 
 func getProtocolVersion(majorVersion, minorVersion int) string {
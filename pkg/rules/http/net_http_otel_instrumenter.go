@@ -15,7 +15,6 @@
 package http
 
 import (
-	"os"
 	"strconv"
 
 	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/utils"
@@ -29,15 +28,7 @@ import (
 	"go.opentelemetry.io/otel/propagation"
 )
 
-type netHttpInnerEnabler struct {
-	enabled bool
-}
-
-func (n netHttpInnerEnabler) Enable() bool {
-	return n.enabled
-}
-
-var netHttpEnabler = netHttpInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_NETHTTP_ENABLED") != "false"}
+var netHttpEnabler = instrumenter.RegisterInstrumentEnabler("nethttp", true)
 
 var emptyHttpResponse = netHttpResponse{}
 
@@ -127,6 +118,22 @@ func (n netHttpClientAttrsGetter) GetServerPort(request *netHttpRequest) int {
 	return port
 }
 
+func (n netHttpClientAttrsGetter) GetHttpRequestBody(request *netHttpRequest) *http.BodyBuffer {
+	return request.body
+}
+
+func (n netHttpClientAttrsGetter) GetHttpResponseBody(request *netHttpRequest, response *netHttpResponse) *http.BodyBuffer {
+	return response.body
+}
+
+func (n netHttpClientAttrsGetter) GetHttpRequestBodySize(request *netHttpRequest) int64 {
+	return request.bodySize
+}
+
+func (n netHttpClientAttrsGetter) GetHttpResponseBodySize(request *netHttpRequest, response *netHttpResponse) int64 {
+	return response.bodySize
+}
+
 type netHttpServerAttrsGetter struct {
 }
 
@@ -209,6 +216,22 @@ func (n netHttpServerAttrsGetter) GetHttpRoute(request *netHttpRequest) string {
 	return request.url.Path
 }
 
+func (n netHttpServerAttrsGetter) GetHttpRequestBody(request *netHttpRequest) *http.BodyBuffer {
+	return request.body
+}
+
+func (n netHttpServerAttrsGetter) GetHttpResponseBody(request *netHttpRequest, response *netHttpResponse) *http.BodyBuffer {
+	return response.body
+}
+
+func (n netHttpServerAttrsGetter) GetHttpRequestBodySize(request *netHttpRequest) int64 {
+	return request.bodySize
+}
+
+func (n netHttpServerAttrsGetter) GetHttpResponseBodySize(request *netHttpRequest, response *netHttpResponse) int64 {
+	return response.bodySize
+}
+
 func BuildNetHttpClientOtelInstrumenter() *instrumenter.PropagatingToDownstreamInstrumenter[*netHttpRequest, *netHttpResponse] {
 	builder := &instrumenter.Builder[*netHttpRequest, *netHttpResponse]{}
 	clientGetter := netHttpClientAttrsGetter{}
@@ -238,6 +261,7 @@ func BuildNetHttpServerOtelInstrumenter() *instrumenter.PropagatingFromUpstreamI
 	return builder.Init().SetSpanStatusExtractor(http.HttpServerSpanStatusExtractor[*netHttpRequest, *netHttpResponse]{Getter: serverGetter}).SetSpanNameExtractor(&http.HttpServerSpanNameExtractor[*netHttpRequest, *netHttpResponse]{Getter: serverGetter}).
 		SetSpanKindExtractor(&instrumenter.AlwaysServerExtractor[*netHttpRequest]{}).
 		AddOperationListeners(http.HttpServerMetrics("net.http.server")).
+		AddContextCustomizers(&http.HttpRouteHolder[*netHttpRequest, *netHttpResponse]{Getter: serverGetter}).
 		SetInstrumentationScope(instrumentation.Scope{
 			Name:    utils.NET_HTTP_SERVER_SCOPE_NAME,
 			Version: version.Tag,
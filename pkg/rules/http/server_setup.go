@@ -23,6 +23,8 @@ import (
 	_ "unsafe"
 
 	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/api"
+	semhttp "github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api-semconv/instrumenter/http"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/utils"
 )
 
 var netHttpServerInstrumenter = BuildNetHttpServerOtelInstrumenter()
@@ -32,7 +34,7 @@ func serverOnEnter(call api.CallContext, _ interface{}, w http.ResponseWriter, r
 	if !netHttpEnabler.Enable() {
 		return
 	}
-	if netHttpFilter.FilterUrl(r.URL) {
+	if utils.FilterHttpRequest(r.Method, r.URL, r.UserAgent()) {
 		return
 	}
 	request := &netHttpRequest{
@@ -42,13 +44,19 @@ func serverOnEnter(call api.CallContext, _ interface{}, w http.ResponseWriter, r
 		version: getProtocolVersion(r.ProtoMajor, r.ProtoMinor),
 		host:    r.Host,
 		isTls:   r.TLS != nil,
+		// the request body is not counted as the handler may not read it all
+		bodySize: getRequestBodySize(r),
 	}
 	ctx := netHttpServerInstrumenter.Start(r.Context(), request)
 	if x, ok := call.GetParam(1).(http.ResponseWriter); ok {
 		x1 := &writerWrapper{ResponseWriter: x, statusCode: http.StatusOK}
 		call.SetParam(1, x1)
 	}
-	call.SetParam(2, r.WithContext(ctx))
+	r = r.WithContext(ctx)
+	if semhttp.BodyCaptureEnabled() {
+		r.Body, request.body = semhttp.CaptureBodyReader(r.Body, r.Header.Get("Content-Type"))
+	}
+	call.SetParam(2, r)
 	data := make(map[string]interface{}, 2)
 	data["ctx"] = ctx
 	data["request"] = request
@@ -74,6 +82,9 @@ func serverOnExit(call api.CallContext) {
 		if w1, ok := p.(*writerWrapper); ok {
 			netHttpServerInstrumenter.End(ctx, request, &netHttpResponse{
 				statusCode: w1.statusCode,
+				header:     w1.Header(),
+				body:       w1.body,
+				bodySize:   w1.written,
 			}, nil)
 		}
 	}
@@ -84,6 +95,36 @@ func serverOnExit(call api.CallContext) {
 type writerWrapper struct {
 	http.ResponseWriter
 	statusCode int
+	// body is captured from the first write on, and dropped as soon as the
+	// response is flushed or hijacked as it is streamed then
+	body      *semhttp.BodyBuffer
+	wroteBody bool
+	streaming bool
+	// written counts the bytes of the response body
+	written int64
+}
+
+func (w *writerWrapper) Write(p []byte) (int, error) {
+	if !w.wroteBody {
+		w.wroteBody = true
+		if semhttp.BodyCaptureEnabled() && !w.streaming {
+			contentType := w.Header().Get("Content-Type")
+			if contentType == "" {
+				contentType = http.DetectContentType(p)
+			}
+			w.body = semhttp.NewBodyBuffer(contentType)
+		}
+	}
+	w.body.Write(p)
+	n, err := w.ResponseWriter.Write(p)
+	w.written += int64(n)
+	return n, err
+}
+
+func (w *writerWrapper) discardBody() {
+	w.streaming = true
+	w.body.Discard()
+	w.body = nil
 }
 
 func (w *writerWrapper) WriteHeader(statusCode int) {
@@ -98,12 +139,14 @@ func (w *writerWrapper) WriteHeader(statusCode int) {
 
 func (w *writerWrapper) Hijack() (rwc net.Conn, buf *bufio.ReadWriter, err error) {
 	if h, ok := w.ResponseWriter.(http.Hijacker); ok {
+		w.discardBody()
 		return h.Hijack()
 	}
 	return nil, nil, fmt.Errorf("responseWriter does not implement http.Hijacker")
 }
 
 func (w *writerWrapper) Flush() {
+	w.discardBody()
 	if f, ok := w.ResponseWriter.(http.Flusher); ok {
 		f.Flush()
 	}
@@ -15,15 +15,7 @@
 package iris
 
 import (
-	"os"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/instrumenter"
 )
 
-type irisInnerEnabler struct {
-	enabled bool
-}
-
-func (k irisInnerEnabler) Enable() bool {
-	return k.enabled
-}
-
-var irisEnabler = irisInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_IRIS_ENABLED") != "false"}
+var irisEnabler = instrumenter.RegisterInstrumentEnabler("iris", true)
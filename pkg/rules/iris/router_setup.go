@@ -18,6 +18,8 @@ import (
 	_ "unsafe"
 
 	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/api"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api-semconv/instrumenter/http"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/utils"
 	iContext "github.com/kataras/iris/v12/context"
 	"go.opentelemetry.io/otel/sdk/trace"
 )
@@ -31,6 +33,12 @@ func irisHttpOnEnter(call api.CallContext, _ interface{}, iCtx *iContext.Context
 		return
 	}
 	r := iCtx.Request()
+	if r != nil && utils.FilterHttpRequest(r.Method, r.URL, r.UserAgent()) {
+		return
+	}
+	if route := iCtx.GetCurrentRoute(); r != nil && route != nil && http.UpdateHttpRoute(r.Context(), route.Path()) {
+		return
+	}
 	lcs := trace.LocalRootSpanFromGLS()
 	if lcs != nil && r != nil && iCtx.Path() != "" && r.URL != nil && (iCtx.Path() != r.URL.Path) {
 		lcs.SetName(iCtx.Path())
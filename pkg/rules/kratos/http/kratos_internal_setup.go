@@ -20,6 +20,7 @@ import (
 	_ "unsafe"
 
 	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/api"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/utils"
 	kt "github.com/go-kratos/kratos/v2"
 	"github.com/go-kratos/kratos/v2/middleware"
 	"github.com/go-kratos/kratos/v2/transport"
@@ -80,6 +81,9 @@ func ServerTracingMiddleWare() middleware.Middleware {
 					request.protocolType = "grpc"
 					sCtx = kratosInternalInstrument.Start(ctx, request)
 				case transport.KindHTTP:
+					if r, ok := http.RequestFromServerContext(ctx); ok && utils.FilterHttpRequest(r.Method, r.URL, r.UserAgent()) {
+						return handler(ctx, req)
+					}
 					request.protocolType = "http"
 					sCtx = kratosInternalInstrument.Start(ctx, request)
 				}
@@ -14,6 +14,8 @@
 
 package langchain
 
+import "github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api-semconv/instrumenter/ai"
+
 type langChainRequest struct {
 	operationName string
 	system        string
@@ -35,10 +37,12 @@ type langChainLLMRequest struct {
 	topP             float64
 	serverAddress    string
 	seed             int64
+	messages         []ai.GenAIMessage
 }
 type langChainLLMResponse struct {
 	responseFinishReasons []string
 	responseModel         string
 	usageOutputTokens     int64
 	responseID            string
+	choices               []ai.GenAIChoice
 }
@@ -14,6 +14,7 @@ type aiLLMRequest struct {
 
 var _ ai.LLMAttrsGetter[langChainLLMRequest, langChainLLMResponse] = aiLLMRequest{}
 var _ ai.CommonAttrsGetter[langChainLLMRequest, any] = aiLLMRequest{}
+var _ ai.MessageGetter[langChainLLMRequest, langChainLLMResponse] = aiLLMRequest{}
 
 func (aiLLMRequest) GetAIOperationName(request langChainLLMRequest) string {
 	return request.operationName
@@ -75,6 +76,12 @@ func (aiLLMRequest) GetAIResponseFinishReasons(request langChainLLMRequest, resp
 func (aiLLMRequest) GetAIResponseModel(request langChainLLMRequest, response langChainLLMResponse) string {
 	return response.responseModel
 }
+func (aiLLMRequest) GetAIRequestMessages(request langChainLLMRequest) []ai.GenAIMessage {
+	return request.messages
+}
+func (aiLLMRequest) GetAIResponseChoices(request langChainLLMRequest, response langChainLLMResponse) []ai.GenAIChoice {
+	return response.choices
+}
 
 var langChainLLMInstrument = BuildLangchainLLMOtelInstrumenter()
 
@@ -83,6 +90,7 @@ func BuildLangchainLLMOtelInstrumenter() instrumenter.Instrumenter[langChainLLMR
 	return builder.Init().SetSpanNameExtractor(&ai.AISpanNameExtractor[langChainLLMRequest, langChainLLMResponse]{Getter: aiLLMRequest{}}).
 		SetSpanKindExtractor(&instrumenter.AlwaysClientExtractor[langChainLLMRequest]{}).
 		AddAttributesExtractor(&ai.AILLMAttrsExtractor[langChainLLMRequest, langChainLLMResponse, aiLLMRequest, aiLLMRequest]{}).
+		AddOperationListeners(ai.AIClientMetrics("langchain.llm")).
 		SetInstrumentationScope(instrumentation.Scope{
 			Name:    utils.LANGCHAIN_SCOPE_NAME,
 			Version: version.Tag,
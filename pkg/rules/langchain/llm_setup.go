@@ -17,9 +17,11 @@ package langchain
 import (
 	"context"
 	"reflect"
+	"strings"
 	_ "unsafe"
 
 	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/api"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api-semconv/instrumenter/ai"
 	"github.com/tmc/langchaingo/llms"
 	"github.com/tmc/langchaingo/llms/ollama"
 	"github.com/tmc/langchaingo/llms/openai"
@@ -54,11 +56,11 @@ func openaiGenerateContentOnExit(call api.CallContext, resp *llms.ContentRespons
 	if !ok {
 		return
 	}
+	request, _ = data["request"].(langChainLLMRequest)
 	if err != nil {
 		langChainLLMInstrument.End(ctx, request, response, err)
 		return
 	}
-	request = data["request"].(langChainLLMRequest)
 
 	if len(resp.Choices) > 0 {
 		var finishReasons []string
@@ -66,17 +68,9 @@ func openaiGenerateContentOnExit(call api.CallContext, resp *llms.ContentRespons
 			finishReasons = append(finishReasons, choice.StopReason)
 		}
 		response.responseFinishReasons = finishReasons
-		if totalTokensAny, ok1 := resp.Choices[0].GenerationInfo["TotalTokens"]; ok1 {
-			if totalTokens, ok2 := totalTokensAny.(int); ok2 {
-				response.usageOutputTokens = int64(totalTokens)
-			}
-		}
-		if reasoningTokensAny, ok1 := resp.Choices[0].GenerationInfo["ReasoningTokens"]; ok1 {
-			if totalTokens, ok2 := reasoningTokensAny.(int); ok2 {
-				request.usageInputTokens = int64(totalTokens)
-			}
-		}
+		setTokenUsage(&request, &response, resp.Choices[0].GenerationInfo)
 	}
+	response.choices = convertChoices(resp)
 
 	langChainLLMInstrument.End(ctx, request, response, nil)
 }
@@ -107,17 +101,16 @@ func ollamaGenerateContentOnExit(call api.CallContext, resp *llms.ContentRespons
 	if !ok {
 		return
 	}
+	request, _ = data["request"].(langChainLLMRequest)
 	if err != nil {
 		langChainLLMInstrument.End(ctx, request, response, err)
 		return
 	}
-	request = data["request"].(langChainLLMRequest)
 
-	if totalTokensAny, ok1 := resp.Choices[0].GenerationInfo["TotalTokens"]; ok1 {
-		if totalTokens, ok2 := totalTokensAny.(int); ok2 {
-			response.usageOutputTokens = int64(totalTokens)
-		}
+	if len(resp.Choices) > 0 {
+		setTokenUsage(&request, &response, resp.Choices[0].GenerationInfo)
 	}
+	response.choices = convertChoices(resp)
 	langChainLLMInstrument.End(ctx, request, response, nil)
 }
 
@@ -140,10 +133,96 @@ func LLMBaseOnEnter(call api.CallContext,
 	req.topK = float64(llmsOpts.TopK)
 	req.topP = llmsOpts.TopP
 	req.seed = int64(llmsOpts.Seed)
+	req.messages = convertMessages(messages)
 
 	langCtx := langChainLLMInstrument.Start(ctx, *req)
+	if streamingFunc := llmsOpts.StreamingFunc; streamingFunc != nil {
+		// the first chunk of the streaming response marks the time to first token
+		call.SetParam(3, append(options, llms.WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
+			ai.RecordFirstToken(langCtx)
+			return streamingFunc(ctx, chunk)
+		})))
+	}
 	data := make(map[string]interface{})
 	data["ctx"] = langCtx
 	data["request"] = *req
 	call.SetData(data)
 }
+
+func setTokenUsage(req *langChainLLMRequest, resp *langChainLLMResponse, generationInfo map[string]any) {
+	if promptTokens, ok := generationInfo["PromptTokens"].(int); ok {
+		req.usageInputTokens = int64(promptTokens)
+	}
+	if completionTokens, ok := generationInfo["CompletionTokens"].(int); ok {
+		resp.usageOutputTokens = int64(completionTokens)
+	}
+}
+
+func convertRole(role llms.ChatMessageType) string {
+	switch role {
+	case llms.ChatMessageTypeSystem:
+		return ai.RoleSystem
+	case llms.ChatMessageTypeAI:
+		return ai.RoleAssistant
+	case llms.ChatMessageTypeTool, llms.ChatMessageTypeFunction:
+		return ai.RoleTool
+	default:
+		return ai.RoleUser
+	}
+}
+
+func convertToolCalls(toolCalls []llms.ToolCall) []ai.GenAIToolCall {
+	var converted []ai.GenAIToolCall
+	for _, toolCall := range toolCalls {
+		c := ai.GenAIToolCall{ID: toolCall.ID, Type: toolCall.Type}
+		if toolCall.FunctionCall != nil {
+			c.Name, c.Arguments = toolCall.FunctionCall.Name, toolCall.FunctionCall.Arguments
+		}
+		converted = append(converted, c)
+	}
+	return converted
+}
+
+// convertMessages only keeps the text, tool calls and tool responses, each
+// tool response is a message of its own.
+func convertMessages(messages []llms.MessageContent) []ai.GenAIMessage {
+	if !ai.MessageContentCaptureEnabled() {
+		return nil
+	}
+	var converted []ai.GenAIMessage
+	for _, message := range messages {
+		m := ai.GenAIMessage{Role: convertRole(message.Role)}
+		var texts []string
+		for _, part := range message.Parts {
+			switch p := part.(type) {
+			case llms.TextContent:
+				texts = append(texts, p.Text)
+			case llms.ToolCall:
+				m.ToolCalls = append(m.ToolCalls, convertToolCalls([]llms.ToolCall{p})...)
+			case llms.ToolCallResponse:
+				converted = append(converted, ai.GenAIMessage{Role: ai.RoleTool, Content: p.Content, ToolCallID: p.ToolCallID})
+			}
+		}
+		if len(texts) > 0 || len(m.ToolCalls) > 0 {
+			m.Content = strings.Join(texts, "\n")
+			converted = append(converted, m)
+		}
+	}
+	return converted
+}
+
+func convertChoices(resp *llms.ContentResponse) []ai.GenAIChoice {
+	if !ai.MessageContentCaptureEnabled() || resp == nil {
+		return nil
+	}
+	var converted []ai.GenAIChoice
+	for i, choice := range resp.Choices {
+		converted = append(converted, ai.GenAIChoice{
+			Index:        i,
+			FinishReason: choice.StopReason,
+			Content:      choice.Content,
+			ToolCalls:    convertToolCalls(choice.ToolCalls),
+		})
+	}
+	return converted
+}
@@ -18,20 +18,12 @@ import (
 	_ "unsafe"
 
 	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/api"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/instrumenter"
 	"github.com/sirupsen/logrus"
 	"go.opentelemetry.io/otel/sdk/trace"
-	"os"
 )
 
-type logrusInnerEnabler struct {
-	enabled bool
-}
-
-func (l logrusInnerEnabler) Enable() bool {
-	return l.enabled
-}
-
-var logrusEnabler = logrusInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_LOGRUS_ENABLED") != "false"}
+var logrusEnabler = instrumenter.RegisterInstrumentEnabler("logrus", true)
 
 //go:linkname logNewOnEnter github.com/sirupsen/logrus.logNewOnEnter
 func logNewOnEnter(call api.CallContext, log *logrus.Logger, formatter logrus.Formatter) {
@@ -16,36 +16,33 @@ package mux
 
 import (
 	"net/http"
-	"os"
 	_ "unsafe"
 
 	"go.opentelemetry.io/otel/sdk/trace"
 
 	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/api"
+	semhttp "github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api-semconv/instrumenter/http"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/instrumenter"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/utils"
 	mux "github.com/gorilla/mux"
 )
 
-type muxInnerEnabler struct {
-	enabled bool
-}
-
-func (m muxInnerEnabler) Enable() bool {
-	return m.enabled
-}
-
-var muxEnabler = muxInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_MUX_ENABLED") != "false"}
+var muxEnabler = instrumenter.RegisterInstrumentEnabler("mux", true)
 
 //go:linkname muxRoute130OnEnter github.com/gorilla/mux.muxRoute130OnEnter
 func muxRoute130OnEnter(call api.CallContext, req *http.Request, route interface{}) {
 	if !muxEnabler.Enable() {
 		return
 	}
-	if req != nil {
+	if req != nil && !utils.FilterHttpRequest(req.Method, req.URL, req.UserAgent()) {
 		lcs := trace.LocalRootSpanFromGLS()
 		if lcs != nil && route != nil {
 			r, ok := route.(*mux.Route)
 			if ok {
 				tmpl, err := r.GetPathTemplate()
+				if err == nil && semhttp.UpdateHttpRoute(req.Context(), tmpl) {
+					return
+				}
 				if err == nil && req.URL != nil && tmpl != req.URL.Path {
 					lcs.SetName(tmpl)
 				}
@@ -61,10 +58,13 @@ func muxRoute174OnEnter(call api.CallContext, req *http.Request, route *mux.Rout
 	if !muxEnabler.Enable() {
 		return
 	}
-	if req != nil {
+	if req != nil && !utils.FilterHttpRequest(req.Method, req.URL, req.UserAgent()) {
 		lcs := trace.LocalRootSpanFromGLS()
 		if lcs != nil && route != nil {
 			tmpl, err := route.GetPathTemplate()
+			if err == nil && semhttp.UpdateHttpRoute(req.Context(), tmpl) {
+				return
+			}
 			if err == nil && req.URL != nil && tmpl != req.URL.Path {
 				lcs.SetName(tmpl)
 			}
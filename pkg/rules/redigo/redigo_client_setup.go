@@ -16,22 +16,14 @@ package redigo
 
 import (
 	"context"
-	"os"
 	_ "unsafe"
 
 	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/api"
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/instrumenter"
 	"github.com/gomodule/redigo/redis"
 )
 
-type redigoInnerEnabler struct {
-	enabled bool
-}
-
-func (r redigoInnerEnabler) Enable() bool {
-	return r.enabled
-}
-
-var redigoEnabler = redigoInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_REDIGO_ENABLED") != "false"}
+var redigoEnabler = instrumenter.RegisterInstrumentEnabler("redigo", true)
 
 //go:linkname onBeforeDialContext github.com/gomodule/redigo/redis.onBeforeDialContext
 func onBeforeDialContext(call api.CallContext, ctx context.Context, network, address string, options ...redis.DialOption) {
@@ -28,11 +28,11 @@ import (
 	"go.opentelemetry.io/otel/sdk/instrumentation"
 	semconv "go.opentelemetry.io/otel/semconv/v1.30.0"
 	"go.opentelemetry.io/otel/trace"
-	"os"
 )
 
-// Instrumentation enabler controller
-var kafkaEnabler = kafkaInnerEnabler{os.Getenv("OTEL_SEGMENTIO_KAFKA_ENABLED") != "false"}
+// Instrumentation enabler controller, the legacy OTEL_SEGMENTIO_KAFKA_ENABLED
+// is honored as OTEL_INSTRUMENTATION_SEGMENTIO_KAFKA_ENABLED
+var kafkaEnabler = instrumenter.RegisterInstrumentEnabler("segmentio-kafka", true, "OTEL_SEGMENTIO_KAFKA_ENABLED")
 
 // Cache Instrumenter instances to avoid repeated creation
 var (
@@ -40,14 +40,6 @@ var (
 	consumerInstrumenter = buildKafkaConsumerInstrumenter()
 )
 
-type kafkaInnerEnabler struct {
-	enabled bool
-}
-
-func (k kafkaInnerEnabler) Enable() bool {
-	return k.enabled
-}
-
 // KafkaProducerCarrier implements OpenTelemetry propagator carrier interface for producers
 type kafkaProducerCarrier struct {
 	messages []*kafka.Message
@@ -255,6 +247,10 @@ func (extractor *kafkaProducerAttributesExtractor) OnStart(attributes []attribut
 		semconv.MessagingDestinationNameKey.String(request.topic),
 		semconv.MessagingOperationName("publish"),
 	}
+	// the batch size is only reported for the batch operations
+	if len(request.msgs) > 1 {
+		kafkaAttributes = append(kafkaAttributes, semconv.MessagingBatchMessageCount(len(request.msgs)))
+	}
 	return append(attributes, kafkaAttributes...), parentContext
 }
 
@@ -289,6 +285,7 @@ func buildKafkaProducerInstrumenter() instrumenter.Instrumenter[kafkaProducerReq
 		SetSpanKindExtractor(&instrumenter.AlwaysProducerExtractor[kafkaProducerReq]{}).
 		SetSpanStatusExtractor(&kafkaProducerStatusExtractor{}).
 		AddAttributesExtractor(&kafkaProducerAttributesExtractor{}).
+		AddOperationListeners(message.MessageMetrics("segmentio-kafka-go.producer", message.PUBLISH)).
 		BuildPropagatingToDownstreamInstrumenter(
 			func(request kafkaProducerReq) propagation.TextMapCarrier {
 				return kafkaProducerCarrier{messages: request.msgs}
@@ -314,6 +311,14 @@ func buildKafkaConsumerInstrumenter() instrumenter.Instrumenter[kafkaConsumerReq
 			Operation: message.PROCESS,
 		}).
 		AddAttributesExtractor(&kafkaConsumerAttributesExtractor{}).
+		AddOperationListeners(message.MessageMetrics("segmentio-kafka-go.consumer", message.PROCESS)).
+		// link the producer context of the message when the consumer span is
+		// not parented to it, e.g. a message received under a local span
+		SetSpanLinksExtractor(&instrumenter.PropagatorSpanLinksExtractor[kafkaConsumerReq]{
+			CarriersGetter: func(request kafkaConsumerReq) []propagation.TextMapCarrier {
+				return []propagation.TextMapCarrier{kafkaConsumerCarrier{message: request.msg}}
+			},
+		}).
 		BuildPropagatingFromUpstreamInstrumenter(
 			func(request kafkaConsumerReq) propagation.TextMapCarrier {
 				return kafkaConsumerCarrier{message: request.msg}
@@ -16,7 +16,6 @@ package trpc
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api-semconv/instrumenter/rpc"
 	"github.com/alibaba/opentelemetry-go-auto-instrumentation/pkg/inst-api/instrumenter"
@@ -30,15 +29,7 @@ import (
 	"trpc.group/trpc-go/trpc-go/codec"
 )
 
-type trpcInnerEnabler struct {
-	enabled bool
-}
-
-func (t trpcInnerEnabler) Enable() bool {
-	return t.enabled
-}
-
-var trpcEnabler = trpcInnerEnabler{os.Getenv("OTEL_INSTRUMENTATION_TRPC_ENABLED") != "false"}
+var trpcEnabler = instrumenter.RegisterInstrumentEnabler("trpc", true)
 
 type trpcClientAttrsGetter struct {
 }
@@ -22,6 +22,8 @@ func init() {
 		NewGeneralTestCase("nethttp-http-2-test", "nethttp", "", "", "1.18", "", TestHttp2),
 		NewGeneralTestCase("nethttp-https-test", "nethttp", "", "", "1.18", "", TestHttps),
 		NewGeneralTestCase("nethttp-metric-test", "nethttp", "", "", "1.18", "", TestHttpMetric),
+		NewGeneralTestCase("nethttp-capture-headers-test", "nethttp", "", "", "1.18", "", TestHttpCaptureHeaders),
+		NewGeneralTestCase("nethttp-capture-body-test", "nethttp", "", "", "1.18", "", TestHttpCaptureBody),
 	)
 }
 
@@ -48,3 +50,17 @@ func TestHttpMetric(t *testing.T, env ...string) {
 	RunGoBuild(t, "go", "build", "test_http_metrics.go", "http_server.go")
 	RunApp(t, "test_http_metrics", env...)
 }
+
+func TestHttpCaptureHeaders(t *testing.T, env ...string) {
+	UseApp("nethttp")
+	RunGoBuild(t, "go", "build", "test_http_headers.go", "http_server.go")
+	env = append(env, "OTEL_INSTRUMENTATION_HTTP_CAPTURE_HEADERS_SERVER_RESPONSE=X-Request-Id")
+	RunApp(t, "test_http_headers", env...)
+}
+
+func TestHttpCaptureBody(t *testing.T, env ...string) {
+	UseApp("nethttp")
+	RunGoBuild(t, "go", "build", "test_http_body.go", "http_server.go")
+	env = append(env, "OTEL_INSTRUMENTATION_HTTP_CAPTURE_BODY=true")
+	RunApp(t, "test_http_body", env...)
+}
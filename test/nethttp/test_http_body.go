@@ -0,0 +1,77 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/test/verifier"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+const slowBody = `{"id":1,"name":"slow"}`
+
+// slowBodyHandler sends a body of known length slowly, the client must not
+// wait for it before returning the response
+func slowBodyHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Length", strconv.Itoa(len(slowBody)))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(slowBody[:8]))
+	w.(http.Flusher).Flush()
+	time.Sleep(2 * time.Second)
+	_, _ = w.Write([]byte(slowBody[8:]))
+}
+
+func setupBodyHttp() {
+	http.HandleFunc("/slow", slowBodyHandler)
+	var err error
+	port, err = verifier.GetFreePort()
+	if err != nil {
+		panic(err)
+	}
+	err = http.ListenAndServe(":"+strconv.Itoa(port), nil)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func main() {
+	go setupBodyHttp()
+	time.Sleep(1 * time.Second)
+	start := time.Now()
+	resp, err := http.Get("http://127.0.0.1:" + strconv.Itoa(port) + "/slow")
+	if err != nil {
+		panic(err)
+	}
+	elapsed := time.Since(start)
+	verifier.Assert(elapsed < time.Second, "Expect the response before the body is sent, took %v", elapsed)
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		panic(err)
+	}
+	_ = resp.Body.Close()
+	verifier.Assert(string(body) == slowBody, "Expect the whole body to be read, got %s", body)
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		verifier.VerifyHttpClientAttributes(stubs[0][0], "GET", "GET", "http://127.0.0.1:"+strconv.Itoa(port)+"/slow", "http", "1.1", "tcp", "ipv4", "", "127.0.0.1:"+strconv.Itoa(port), 200, 0, int64(port))
+		events := stubs[0][0].Events
+		verifier.Assert(len(events) == 1 && events[0].Name == "http.response.body", "Expect the response body event, got %v", events)
+		content := verifier.GetAttribute(events[0].Attributes, "http.response.body.content").AsString()
+		verifier.Assert(content == slowBody, "Expect the whole response body to be captured, got %s", content)
+	}, 1)
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2025 Alibaba Group Holding Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/alibaba/opentelemetry-go-auto-instrumentation/test/verifier"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func headersHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-Request-Id", "req-1")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("success"))
+}
+
+func setupHeadersHttp() {
+	http.HandleFunc("/headers", headersHandler)
+	var err error
+	port, err = verifier.GetFreePort()
+	if err != nil {
+		panic(err)
+	}
+	err = http.ListenAndServe(":"+strconv.Itoa(port), nil)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func main() {
+	go setupHeadersHttp()
+	time.Sleep(1 * time.Second)
+	resp, err := http.Get("http://127.0.0.1:" + strconv.Itoa(port) + "/headers")
+	if err != nil {
+		panic(err)
+	}
+	defer resp.Body.Close()
+	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
+		// the client span and the server span of the request
+		verifier.VerifyHttpServerAttributes(stubs[0][1], "GET /headers", "GET", "http", "tcp", "ipv4", "", "127.0.0.1:"+strconv.Itoa(port), "Go-http-client/1.1", "http", "/headers", "", "/headers", 200)
+		header := verifier.GetAttribute(stubs[0][1].Attributes, "http.response.header.x-request-id").AsStringSlice()
+		verifier.Assert(len(header) == 1 && header[0] == "req-1", "Expect the captured response header, got %v", header)
+	}, 1)
+}
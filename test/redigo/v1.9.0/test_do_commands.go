@@ -18,7 +18,7 @@ func main() {
 	c.Do("GET", "foo")
 
 	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
-		verifier.VerifyDbAttributes(stubs[0][0], "SET", "redis", "localhost", "SET foo bar", "SET", "", nil)
+		verifier.VerifyDbAttributes(stubs[0][0], "SET", "redis", "localhost", "SET foo ?", "SET", "", nil)
 		verifier.VerifyDbAttributes(stubs[1][0], "GET", "redis", "localhost", "GET foo", "GET", "", nil)
 	}, 2)
 }
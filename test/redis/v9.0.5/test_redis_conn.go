@@ -53,9 +53,9 @@ func main() {
 		panic(err)
 	}
 	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
-		verifier.VerifyDbAttributes(stubs[0][0], "client", "redis", "localhost", "client setname myclient", "client", "", nil)
+		verifier.VerifyDbAttributes(stubs[0][0], "client", "redis", "localhost", "client setname ?", "client", "", nil)
 		verifier.VerifyDbAttributes(stubs[1][0], "client", "redis", "localhost", "client getname", "client", "", nil)
-		verifier.VerifyDbAttributes(stubs[2][0], "set", "redis", "localhost", "set a b ex 5", "set", "", nil)
+		verifier.VerifyDbAttributes(stubs[2][0], "set", "redis", "localhost", "set a ? ? ?", "set", "", nil)
 		verifier.VerifyDbAttributes(stubs[3][0], "get", "redis", "localhost", "get a", "get", "", nil)
 	}, 4)
 }
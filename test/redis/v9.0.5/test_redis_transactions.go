@@ -43,7 +43,24 @@ func main() {
 
 	// The value is available only after Exec is called.
 	fmt.Println(incr.Val())
+
+	// MULTI and EXEC wrap the commands of a transaction
+	txPipe := rdb.TxPipeline()
+	txIncr := txPipe.Incr(ctx, "tx_pipeline_counter")
+	txPipe.Expire(ctx, "tx_pipeline_counter", time.Hour)
+	_, err = txPipe.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(txIncr.Val())
 	verifier.WaitAndAssertTraces(func(stubs []tracetest.SpanStubs) {
-		verifier.VerifyDbAttributes(stubs[0][0], "pipeline", "redis", "localhost", "pipeline incr/expire/", "pipeline", "", nil)
-	}, 1)
+		verifier.VerifyDbAttributes(stubs[0][0], "pipeline", "redis", "localhost", "incr/expire", "pipeline", "", nil)
+		batchSize := verifier.GetAttribute(stubs[0][0].Attributes, "db.operation.batch.size").AsInt64()
+		verifier.Assert(batchSize == 2, "Expect the batch size to be 2, got %d", batchSize)
+		verifier.Assert(len(stubs[0][0].Events) == 2, "Expect an event per command, got %d", len(stubs[0][0].Events))
+
+		verifier.VerifyDbAttributes(stubs[1][0], "multi", "redis", "localhost", "incr/expire", "multi", "", nil)
+		txBatchSize := verifier.GetAttribute(stubs[1][0].Attributes, "db.operation.batch.size").AsInt64()
+		verifier.Assert(txBatchSize == 2, "Expect the batch size of the transaction to be 2, got %d", txBatchSize)
+	}, 2)
 }
@@ -17,6 +17,7 @@ package preprocess
 import (
 	"bufio"
 	_ "embed"
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
@@ -847,6 +848,14 @@ func (dp *DepProcessor) addRuleImporter() error {
 		content += s
 		cnt++
 	}
+	// Embed the matched rules so that they can be inspected at runtime via
+	// the self-diagnostics endpoint
+	manifest, err := json.Marshal(dp.bundles)
+	if err != nil {
+		return err
+	}
+	content += fmt.Sprintf("//go:linkname otelInstrumentationManifest %s/core/diagnostics.InstrumentationManifest\n", pkgPrefix)
+	content += fmt.Sprintf("var otelInstrumentationManifest = %q\n", manifest)
 	util.WriteFile(dp.otelImporter, content)
 	return nil
 }